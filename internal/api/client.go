@@ -12,8 +12,10 @@ import (
 	"github.com/shurcooL/graphql"
 	"golang.org/x/time/rate"
 
+	"github.com/swfz/gh-deps/internal/filter"
 	"github.com/swfz/gh-deps/internal/models"
 	"github.com/swfz/gh-deps/internal/parser"
+	"github.com/swfz/gh-deps/internal/pattern"
 )
 
 // Client wraps GitHub API client with rate limiting
@@ -24,10 +26,27 @@ type Client struct {
 	verbose             bool
 	skipChecks          bool
 	excludeRepositories map[string]bool
+	excludePatterns     []*pattern.Pattern      // Glob/regex exclusions
+	includePatterns     []*pattern.Pattern      // Glob/regex inclusions for listings (empty = all)
+	bots                map[models.BotType]bool // Bot types to include (empty = all)
+	repositoryFilter    RepositoryFilter        // Topic/language/visibility/name filter
+	filter              *filter.Filter          // PR filter expression (nil = all)
+}
+
+// ClientOptions configures which repositories and PRs a Client returns
+type ClientOptions struct {
+	Verbose             bool             // Enable debug output
+	SkipChecks          bool             // Skip fetching check runs
+	ExcludeRepositories []string         // Repositories to exclude (owner/repo, short name, or pattern)
+	IncludePatterns     []string         // Repository patterns listed repositories must match
+	Targets             []models.Target  // Targets used to resolve short excluded names
+	Bots                []models.BotType // Bot types to include (empty = all)
+	RepositoryFilter    RepositoryFilter // Filter applied to listed repositories
+	Filter              *filter.Filter   // PR filter expression (nil = all)
 }
 
 // NewClient creates a new GitHub API client using gh CLI authentication
-func NewClient(verbose bool, skipChecks bool, excludeRepositories []string, target string, isOrganization bool) (*Client, error) {
+func NewClient(opts ClientOptions) (*Client, error) {
 	// Use gh CLI's authentication
 	httpClient, err := api.DefaultHTTPClient()
 	if err != nil {
@@ -42,40 +61,115 @@ func NewClient(verbose bool, skipChecks bool, excludeRepositories []string, targ
 
 	// Convert excluded repositories list to map for efficient lookup
 	// Normalize repo names: short form (reponame) gets target prefix, full form (owner/repo) used as-is
+	// Glob and regex patterns are kept separately and matched against each repository
 	excludeMap := make(map[string]bool)
-	for _, repo := range excludeRepositories {
-		if strings.Contains(repo, "/") {
+	var excludePatterns []*pattern.Pattern
+	for _, repo := range opts.ExcludeRepositories {
+		if pattern.IsPattern(repo) {
+			p, err := pattern.Compile(repo)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+			}
+			excludePatterns = append(excludePatterns, p)
+		} else if strings.Contains(repo, "/") {
 			// Full format (owner/repo): use as-is to support excluding repos from other orgs
 			excludeMap[repo] = true
 		} else {
-			// Short format (reponame): add target prefix (org or user)
-			excludeMap[target+"/"+repo] = true
+			// Short format (reponame): add each target prefix (org or user)
+			for _, target := range opts.Targets {
+				excludeMap[target.Name+"/"+repo] = true
+			}
 			// Also add the bare name for backward compatibility
 			excludeMap[repo] = true
 		}
 	}
 
+	includePatterns, err := pattern.CompileAll(opts.IncludePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository pattern: %w", err)
+	}
+
+	botMap := make(map[models.BotType]bool)
+	for _, bot := range opts.Bots {
+		botMap[bot] = true
+	}
+
 	return &Client{
 		graphqlClient:       graphqlClient,
 		httpClient:          httpClient,
 		rateLimiter:         rateLimiter,
-		verbose:             verbose,
-		skipChecks:          skipChecks,
+		verbose:             opts.Verbose,
+		skipChecks:          opts.SkipChecks,
 		excludeRepositories: excludeMap,
+		excludePatterns:     excludePatterns,
+		includePatterns:     includePatterns,
+		bots:                botMap,
+		repositoryFilter:    opts.RepositoryFilter,
+		filter:              opts.Filter,
 	}, nil
 }
 
-// FetchOrgPullRequests fetches all dependency update PRs from an organization
-func (c *Client) FetchOrgPullRequests(ctx context.Context, orgName string, limit int) ([]models.PullRequest, error) {
+// FetchPullRequests fetches dependency update PRs from all targets.
+// Results are merged into a single list without duplicates, and the limit
+// applies to the combined list.
+func (c *Client) FetchPullRequests(ctx context.Context, targets []models.Target, limit int) ([]models.PullRequest, error) {
 	var allPRs []models.PullRequest
-	var cursor *string
+	seen := make(map[string]bool)
 
-	for {
-		// Wait for rate limiter
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return nil, fmt.Errorf("rate limiter error: %w", err)
+	for _, target := range targets {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - len(allPRs)
 		}
 
+		prs, err := c.FetchTargetPullRequests(ctx, target, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch PRs from %s %s: %w", target.Kind(), target.DisplayName(), err)
+		}
+
+		for _, pr := range prs {
+			key := fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			allPRs = append(allPRs, pr)
+		}
+
+		if limit > 0 && len(allPRs) >= limit {
+			return allPRs[:limit], nil
+		}
+	}
+
+	return allPRs, nil
+}
+
+// FetchTargetPullRequests fetches dependency update PRs from an organization, team or user
+func (c *Client) FetchTargetPullRequests(ctx context.Context, target models.Target, limit int) ([]models.PullRequest, error) {
+	switch {
+	case target.Team != "":
+		return c.FetchTeamPullRequests(ctx, target.Name, target.Team, limit)
+	case target.IsOrganization:
+		return c.FetchOrgPullRequests(ctx, target.Name, limit)
+	default:
+		return c.FetchUserPullRequests(ctx, target.Name, limit)
+	}
+}
+
+// repositoryPage is a single page of a paginated repository listing
+type repositoryPage struct {
+	nodes       []RepositoryNode
+	hasNextPage bool
+	endCursor   string
+}
+
+// FetchOrgPullRequests fetches all dependency update PRs from an organization
+func (c *Client) FetchOrgPullRequests(ctx context.Context, orgName string, limit int) ([]models.PullRequest, error) {
+	if c.repositoryFilter.hasSearchQualifiers() {
+		return c.searchPullRequests(ctx, "org:"+orgName, limit)
+	}
+
+	return c.fetchRepositoryPages(ctx, limit, func(cursor *string) (*repositoryPage, error) {
 		var query OrgRepositoriesQuery
 
 		variables := map[string]interface{}{
@@ -87,40 +181,104 @@ func (c *Client) FetchOrgPullRequests(ctx context.Context, orgName string, limit
 			return nil, fmt.Errorf("GraphQL query failed: %w", err)
 		}
 
-		// Process repositories and PRs
-		for _, repo := range query.Organization.Repositories.Nodes {
-			// Skip excluded repositories
-			if c.excludeRepositories[repo.NameWithOwner] {
-				if c.verbose {
-					fmt.Fprintf(os.Stderr, "[DEBUG] Skipping excluded repository: %s\n", repo.NameWithOwner)
-				}
-				continue
-			}
+		repos := query.Organization.Repositories
+		return &repositoryPage{
+			nodes:       repos.Nodes,
+			hasNextPage: repos.PageInfo.HasNextPage,
+			endCursor:   repos.PageInfo.EndCursor,
+		}, nil
+	})
+}
 
-			prs := c.processPRsFromRepo(ctx, repo, c.verbose)
-			allPRs = append(allPRs, prs...)
+// FetchUserPullRequests fetches all dependency update PRs from a user's repositories
+func (c *Client) FetchUserPullRequests(ctx context.Context, userName string, limit int) ([]models.PullRequest, error) {
+	if c.repositoryFilter.hasSearchQualifiers() {
+		return c.searchPullRequests(ctx, "user:"+userName, limit)
+	}
 
-			// Check PR limit after adding PRs from this repo
-			if limit > 0 && len(allPRs) >= limit {
-				if c.verbose {
-					fmt.Fprintf(os.Stderr, "[DEBUG] Reached PR limit (%d), stopping\n", limit)
-				}
-				return allPRs[:limit], nil
-			}
+	return c.fetchRepositoryPages(ctx, limit, func(cursor *string) (*repositoryPage, error) {
+		var query UserRepositoriesQuery
+
+		variables := map[string]interface{}{
+			"userName": graphql.String(userName),
+			"cursor":   (*graphql.String)(cursor),
 		}
 
-		// Check if there are more pages
-		if !query.Organization.Repositories.PageInfo.HasNextPage {
-			break
+		if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		repos := query.User.Repositories
+		return &repositoryPage{
+			nodes:       repos.Nodes,
+			hasNextPage: repos.PageInfo.HasNextPage,
+			endCursor:   repos.PageInfo.EndCursor,
+		}, nil
+	})
+}
+
+// FetchTeamPullRequests fetches all dependency update PRs from the repositories of a team
+func (c *Client) FetchTeamPullRequests(ctx context.Context, orgName, teamSlug string, limit int) ([]models.PullRequest, error) {
+	return c.fetchRepositoryPages(ctx, limit, func(cursor *string) (*repositoryPage, error) {
+		var query TeamRepositoriesQuery
+
+		variables := map[string]interface{}{
+			"orgName":  graphql.String(orgName),
+			"teamSlug": graphql.String(teamSlug),
+			"cursor":   (*graphql.String)(cursor),
+		}
+
+		if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
 		}
-		cursor = &query.Organization.Repositories.PageInfo.EndCursor
+
+		if query.Organization.Team == nil {
+			return nil, fmt.Errorf("team not found: %s/%s", orgName, teamSlug)
+		}
+
+		repos := query.Organization.Team.Repositories
+		return &repositoryPage{
+			nodes:       repos.Nodes,
+			hasNextPage: repos.PageInfo.HasNextPage,
+			endCursor:   repos.PageInfo.EndCursor,
+		}, nil
+	})
+}
+
+// searchPullRequests fetches dependency update PRs from repositories found by
+// a repository search scoped with ownerQualifier (e.g., "org:my-org")
+func (c *Client) searchPullRequests(ctx context.Context, ownerQualifier string, limit int) ([]models.PullRequest, error) {
+	searchQuery := c.repositoryFilter.searchQuery(ownerQualifier)
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Searching repositories: %s\n", searchQuery)
 	}
 
-	return allPRs, nil
+	return c.fetchRepositoryPages(ctx, limit, func(cursor *string) (*repositoryPage, error) {
+		var query SearchRepositoriesQuery
+
+		variables := map[string]interface{}{
+			"query":  graphql.String(searchQuery),
+			"cursor": (*graphql.String)(cursor),
+		}
+
+		if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+
+		page := &repositoryPage{
+			hasNextPage: query.Search.PageInfo.HasNextPage,
+			endCursor:   query.Search.PageInfo.EndCursor,
+		}
+		for _, node := range query.Search.Nodes {
+			page.nodes = append(page.nodes, node.Repository)
+		}
+		return page, nil
+	})
 }
 
-// FetchUserPullRequests fetches all dependency update PRs from a user's repositories
-func (c *Client) FetchUserPullRequests(ctx context.Context, userName string, limit int) ([]models.PullRequest, error) {
+// fetchRepositoryPages walks a paginated repository listing and collects
+// dependency update PRs from every repository that passes the filters
+func (c *Client) fetchRepositoryPages(ctx context.Context, limit int, fetchPage func(cursor *string) (*repositoryPage, error)) ([]models.PullRequest, error) {
 	var allPRs []models.PullRequest
 	var cursor *string
 
@@ -130,24 +288,14 @@ func (c *Client) FetchUserPullRequests(ctx context.Context, userName string, lim
 			return nil, fmt.Errorf("rate limiter error: %w", err)
 		}
 
-		var query UserRepositoriesQuery
-
-		variables := map[string]interface{}{
-			"userName": graphql.String(userName),
-			"cursor":   (*graphql.String)(cursor),
-		}
-
-		if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
-			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		page, err := fetchPage(cursor)
+		if err != nil {
+			return nil, err
 		}
 
 		// Process repositories and PRs
-		for _, repo := range query.User.Repositories.Nodes {
-			// Skip excluded repositories
-			if c.excludeRepositories[repo.NameWithOwner] {
-				if c.verbose {
-					fmt.Fprintf(os.Stderr, "[DEBUG] Skipping excluded repository: %s\n", repo.NameWithOwner)
-				}
+		for _, repo := range page.nodes {
+			if !c.includeRepository(repo.RepositoryInfo) {
 				continue
 			}
 
@@ -164,15 +312,57 @@ func (c *Client) FetchUserPullRequests(ctx context.Context, userName string, lim
 		}
 
 		// Check if there are more pages
-		if !query.User.Repositories.PageInfo.HasNextPage {
+		if !page.hasNextPage {
 			break
 		}
-		cursor = &query.User.Repositories.PageInfo.EndCursor
+		cursor = &page.endCursor
 	}
 
 	return allPRs, nil
 }
 
+// IsExcluded reports whether a repository (owner/repo) is excluded by name or pattern
+func (c *Client) IsExcluded(nameWithOwner string) bool {
+	return c.excludeRepositories[nameWithOwner] || pattern.MatchAnyRepository(c.excludePatterns, nameWithOwner)
+}
+
+// includeRepository returns false for archived, excluded and filtered-out repositories
+func (c *Client) includeRepository(repo RepositoryInfo) bool {
+	// Skip archived repositories (team listings include them)
+	if repo.IsArchived {
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Skipping archived repository: %s\n", repo.NameWithOwner)
+		}
+		return false
+	}
+
+	// Skip excluded repositories
+	if c.IsExcluded(repo.NameWithOwner) {
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Skipping excluded repository: %s\n", repo.NameWithOwner)
+		}
+		return false
+	}
+
+	// Skip repositories not matching the --repo patterns
+	if len(c.includePatterns) > 0 && !pattern.MatchAnyRepository(c.includePatterns, repo.NameWithOwner) {
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Skipping repository not matching --repo patterns: %s\n", repo.NameWithOwner)
+		}
+		return false
+	}
+
+	// Skip repositories not matching the topic/language/visibility/name filter
+	if !c.repositoryFilter.Match(repo) {
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Skipping filtered repository: %s\n", repo.NameWithOwner)
+		}
+		return false
+	}
+
+	return true
+}
+
 // FetchRepositoryPullRequests fetches PRs for a specific repository
 func (c *Client) FetchRepositoryPullRequests(ctx context.Context, owner, repo string) ([]models.PullRequest, error) {
 	// Wait for rate limiter
@@ -221,6 +411,14 @@ func (c *Client) processPRsFromRepo(ctx context.Context, repo RepositoryNode, ve
 			fmt.Fprintf(os.Stderr, "[DEBUG] PR #%d is %s bot\n", pr.Number, botType)
 		}
 
+		// Skip bots that were not selected
+		if len(c.bots) > 0 && !c.bots[botType] {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "[DEBUG] PR #%d skipped (bot %s not selected)\n", pr.Number, botType)
+			}
+			continue
+		}
+
 		// Get check status from statusCheckRollup (efficient - no extra API call)
 		var checkSummary models.CheckSummary
 		if !c.skipChecks && len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
@@ -244,7 +442,8 @@ func (c *Client) processPRsFromRepo(ctx context.Context, repo RepositoryNode, ve
 		}
 
 		// Create PR model
-		prs = append(prs, models.PullRequest{
+		version := parser.ExtractVersion(pr.Body, botType)
+		pullRequest := models.PullRequest{
 			Repository:     repo.NameWithOwner,
 			Number:         pr.Number,
 			Title:          pr.Title,
@@ -253,12 +452,29 @@ func (c *Client) processPRsFromRepo(ctx context.Context, repo RepositoryNode, ve
 			CreatedAt:      pr.CreatedAt,
 			URL:            pr.URL,
 			HeadSHA:        pr.HeadRefOid,
+			HeadRef:        pr.HeadRefName,
+			BaseRef:        pr.BaseRefName,
+			Additions:      pr.Additions,
+			Deletions:      pr.Deletions,
+			ReviewDecision: models.ReviewDecision(pr.ReviewDecision),
 			BotType:        botType,
 			CheckSummary:   checkSummary,
-			Version:        parser.ExtractVersion(pr.Body, botType),
+			Version:        version,
+			Dependency:     parser.ExtractDependency(pr.Title, botType),
+			UpdateType:     parser.ClassifyUpdate(version),
 			MergeableState: models.MergeableState(pr.Mergeable),
 			Labels:         labels,
-		})
+		}
+
+		// Skip PRs not matching the --filter expression
+		if c.filter != nil && !c.filter.Match(pullRequest) {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "[DEBUG] PR #%d skipped (does not match filter)\n", pr.Number)
+			}
+			continue
+		}
+
+		prs = append(prs, pullRequest)
 	}
 
 	return prs
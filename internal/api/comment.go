@@ -75,3 +75,13 @@ func (c *Client) CreateComment(ctx context.Context, owner, repo string, prNumber
 
 	return &commentResp, nil
 }
+
+// DeleteComment deletes a PR comment
+func (c *Client) DeleteComment(ctx context.Context, owner, repo string, commentID int) error {
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Deleting comment %d in %s/%s\n", commentID, owner, repo)
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/issues/comments/%d", owner, repo, commentID)
+	return c.doREST(ctx, "DELETE", path, nil, nil, "comment deletion failed")
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shurcooL/graphql"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// FetchPullRequestDetail fetches the checks, changed files, reviews and comments of a PR
+func (c *Client) FetchPullRequestDetail(ctx context.Context, owner, repo string, prNumber int) (*models.PullRequestDetail, error) {
+	// Wait for rate limiter
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Fetching details of PR %s/%s#%d\n", owner, repo, prNumber)
+	}
+
+	var query PullRequestDetailQuery
+	variables := map[string]interface{}{
+		"owner":  graphql.String(owner),
+		"repo":   graphql.String(repo),
+		"number": graphql.Int(prNumber),
+	}
+
+	if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
+		return nil, fmt.Errorf("GraphQL query failed: %w", err)
+	}
+
+	pr := query.Repository.PullRequest
+	detail := &models.PullRequestDetail{
+		ChangedFiles:  pr.ChangedFiles,
+		TotalComments: pr.Comments.TotalCount,
+	}
+
+	if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		for _, node := range pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+			detail.Checks = append(detail.Checks, checkRunFromContext(node))
+		}
+	}
+
+	for _, file := range pr.Files.Nodes {
+		detail.Files = append(detail.Files, models.ChangedFile{
+			Path:      file.Path,
+			Additions: file.Additions,
+			Deletions: file.Deletions,
+		})
+	}
+
+	for _, review := range pr.Reviews.Nodes {
+		r := models.Review{
+			Author: review.Author.Login,
+			State:  review.State,
+			Body:   review.Body,
+		}
+		if review.SubmittedAt != nil {
+			r.SubmittedAt = *review.SubmittedAt
+		}
+		detail.Reviews = append(detail.Reviews, r)
+	}
+
+	for _, comment := range pr.Comments.Nodes {
+		detail.Comments = append(detail.Comments, models.Comment{
+			Author:    comment.Author.Login,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+
+	return detail, nil
+}
+
+// checkRunFromContext converts a check run or commit status into a CheckRun
+// using the REST API's lowercase status and conclusion values
+func checkRunFromContext(node CheckContextNode) models.CheckRun {
+	if node.Typename == "StatusContext" {
+		ctx := node.StatusContext
+		check := models.CheckRun{Name: ctx.Context, Status: "completed", URL: ctx.TargetURL}
+		switch ctx.State {
+		case "PENDING", "EXPECTED":
+			check.Status = "in_progress"
+		case "ERROR":
+			check.Conclusion = "failure"
+		default:
+			check.Conclusion = strings.ToLower(ctx.State)
+		}
+		return check
+	}
+
+	run := node.CheckRun
+	return models.CheckRun{
+		Name:       run.Name,
+		Status:     strings.ToLower(run.Status),
+		Conclusion: strings.ToLower(run.Conclusion),
+		URL:        run.DetailsURL,
+	}
+}
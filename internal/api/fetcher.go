@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// FetchSpec selects the PRs to fetch.
+// Exclusions, repository patterns and filters, bots and the PR filter
+// expression are applied by the Client the Fetcher uses.
+type FetchSpec struct {
+	Targets      []models.Target // Orgs, teams and users (the first one owns short repository names)
+	Repositories []string        // Specific repositories (owner/repo or short name)
+	Patterns     []string        // Repository patterns the targets' repositories are listed for
+	Limit        int             // Maximum number of PRs (0 = unlimited)
+}
+
+// Fetcher fetches the PRs selected by a FetchSpec.
+// The initial load, refreshes and polling share it so that they all honor
+// the same selection.
+type Fetcher struct {
+	client   *Client
+	spec     FetchSpec
+	progress io.Writer // Progress messages (nil = silent)
+}
+
+// NewFetcher creates a fetcher for the spec.
+// Progress messages are written to progress unless it is nil.
+func NewFetcher(client *Client, spec FetchSpec, progress io.Writer) *Fetcher {
+	return &Fetcher{client: client, spec: spec, progress: progress}
+}
+
+// Silent returns a copy of the fetcher that writes no progress messages
+func (f *Fetcher) Silent() *Fetcher {
+	silent := *f
+	silent.progress = nil
+	return &silent
+}
+
+// Fetch fetches the PRs selected by the spec
+func (f *Fetcher) Fetch(ctx context.Context) ([]models.PullRequest, error) {
+	spec := f.spec
+	if len(spec.Repositories) == 0 && len(spec.Patterns) == 0 {
+		f.printTargets()
+		return f.client.FetchPullRequests(ctx, spec.Targets, spec.Limit)
+	}
+
+	var allPRs []models.PullRequest
+	if len(spec.Repositories) > 0 {
+		// Fetch PRs from specific repositories
+		f.printf("Fetching dependency PRs from specific repositories: %v\n", spec.Repositories)
+		prs, err := f.fetchRepositories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		allPRs = prs
+	}
+
+	if len(spec.Patterns) > 0 && (spec.Limit == 0 || len(allPRs) < spec.Limit) {
+		// Fetch PRs from target repositories matching the --repo patterns
+		f.printf("Fetching dependency PRs from repositories matching: %v\n", spec.Patterns)
+		f.printTargets()
+
+		remaining := 0
+		if spec.Limit > 0 {
+			remaining = spec.Limit - len(allPRs)
+		}
+		prs, err := f.client.FetchPullRequests(ctx, spec.Targets, remaining)
+		if err != nil {
+			return nil, err
+		}
+		allPRs = appendUniquePRs(allPRs, prs)
+	}
+
+	return allPRs, nil
+}
+
+// FetchRepository fetches the PRs of a single repository (owner/repo), e.g. for polling
+func (f *Fetcher) FetchRepository(ctx context.Context, repository string) ([]models.PullRequest, error) {
+	owner, name, err := ParseRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+	return f.client.FetchRepositoryPullRequests(ctx, owner, name)
+}
+
+// fetchRepositories fetches PRs from the specific repositories.
+// Note: archived repositories are not filtered here because explicitly specifying
+// a repository via --repo is treated as an intentional user choice.
+func (f *Fetcher) fetchRepositories(ctx context.Context) ([]models.PullRequest, error) {
+	var allPRs []models.PullRequest
+
+	for _, repo := range f.spec.Repositories {
+		owner, name, err := ParseRepository(repo)
+		if err != nil {
+			// Short format (reponame): use target as owner
+			// (only allowed with a single target, see app.ParseConfig)
+			owner = f.spec.Targets[0].Name
+			name = repo
+		}
+
+		// Exclusions also apply to explicitly specified repositories
+		if f.client.IsExcluded(owner + "/" + name) {
+			f.printf("Skipping excluded repository: %s/%s\n", owner, name)
+			continue
+		}
+
+		f.printf("Fetching dependency PRs from repository: %s/%s\n", owner, name)
+
+		prs, err := f.client.FetchRepositoryPullRequests(ctx, owner, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch PRs from %s/%s: %w", owner, name, err)
+		}
+		allPRs = append(allPRs, prs...)
+
+		if f.spec.Limit > 0 && len(allPRs) >= f.spec.Limit {
+			return allPRs[:f.spec.Limit], nil
+		}
+	}
+
+	return allPRs, nil
+}
+
+// printTargets prints the targets being fetched
+func (f *Fetcher) printTargets() {
+	limitMsg := "all PRs"
+	if f.spec.Limit > 0 {
+		limitMsg = fmt.Sprintf("up to %d PRs", f.spec.Limit)
+	}
+	for _, target := range f.spec.Targets {
+		f.printf("Fetching dependency PRs from %s: %s (%s)\n", target.Kind(), target.DisplayName(), limitMsg)
+	}
+}
+
+// printf writes a progress message
+func (f *Fetcher) printf(format string, args ...interface{}) {
+	if f.progress != nil {
+		fmt.Fprintf(f.progress, format, args...)
+	}
+}
+
+// appendUniquePRs appends PRs that are not already in the list
+func appendUniquePRs(prs, more []models.PullRequest) []models.PullRequest {
+	seen := make(map[string]bool)
+	for _, pr := range prs {
+		seen[fmt.Sprintf("%s#%d", pr.Repository, pr.Number)] = true
+	}
+	for _, pr := range more {
+		if !seen[fmt.Sprintf("%s#%d", pr.Repository, pr.Number)] {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
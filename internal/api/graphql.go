@@ -4,24 +4,47 @@ import "time"
 
 // GraphQL query structures for fetching organization/user repositories with PRs
 
+// RepositoryInfo represents the repository metadata used for repository selection
+type RepositoryInfo struct {
+	NameWithOwner   string
+	Name            string
+	IsArchived      bool
+	Visibility      string // PUBLIC, PRIVATE, INTERNAL
+	PrimaryLanguage *struct {
+		Name string
+	}
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string
+			}
+		}
+	} `graphql:"repositoryTopics(first: 20)"`
+}
+
 // RepositoryNode represents a repository with its pull requests
 type RepositoryNode struct {
-	NameWithOwner string
-	PullRequests  struct {
+	RepositoryInfo
+	PullRequests struct {
 		Nodes []PullRequestNode
 	} `graphql:"pullRequests(first: 100, states: OPEN)"`
 }
 
 // PullRequestNode represents a pull request with its metadata
 type PullRequestNode struct {
-	Number     int
-	Title      string
-	Body       string
-	CreatedAt  time.Time
-	URL        string
-	HeadRefOid string
-	Mergeable  string // MERGEABLE, CONFLICTING, UNKNOWN
-	Author     struct {
+	Number         int
+	Title          string
+	Body           string
+	CreatedAt      time.Time
+	URL            string
+	HeadRefOid     string
+	HeadRefName    string
+	BaseRefName    string
+	Additions      int
+	Deletions      int
+	ReviewDecision string // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, or null
+	Mergeable      string // MERGEABLE, CONFLICTING, UNKNOWN
+	Author         struct {
 		Login string
 	}
 	Commits struct {
@@ -69,7 +92,143 @@ type UserRepositoriesQuery struct {
 	} `graphql:"user(login: $userName)"`
 }
 
+// TeamRepositoriesQuery represents the GraphQL query for repositories a team has access to
+// Team repositories include archived ones, which are skipped when processing results
+type TeamRepositoriesQuery struct {
+	Organization struct {
+		Team *struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+				Nodes []RepositoryNode
+			} `graphql:"repositories(first: 50, after: $cursor)"`
+		} `graphql:"team(slug: $teamSlug)"`
+	} `graphql:"organization(login: $orgName)"`
+}
+
+// SearchRepositoriesQuery represents the GraphQL repository search query
+// Used when topic, language or visibility filters narrow down the repositories
+type SearchRepositoriesQuery struct {
+	Search struct {
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   string
+		}
+		Nodes []struct {
+			Repository RepositoryNode `graphql:"... on Repository"`
+		}
+	} `graphql:"search(query: $query, type: REPOSITORY, first: 50, after: $cursor)"`
+}
+
 // RepositoryPRsQuery represents the GraphQL query for a single repository's PRs
 type RepositoryPRsQuery struct {
 	Repository RepositoryNode `graphql:"repository(owner: $owner, name: $repo)"`
 }
+
+// ClosedPullRequestNode represents a merged or closed pull request found by search
+type ClosedPullRequestNode struct {
+	Number    int
+	Title     string
+	CreatedAt time.Time
+	ClosedAt  time.Time
+	Merged    bool
+	Author    struct {
+		Login string
+	}
+	Repository RepositoryInfo
+}
+
+// ClosedPullRequestSearchQuery represents the GraphQL query for searching merged and closed pull requests
+type ClosedPullRequestSearchQuery struct {
+	Search struct {
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   string
+		}
+		Nodes []struct {
+			PullRequest ClosedPullRequestNode `graphql:"... on PullRequest"`
+		}
+	} `graphql:"search(query: $query, type: ISSUE, first: 100, after: $cursor)"`
+}
+
+// TeamRepositoryNamesQuery represents the GraphQL query for a team's repositories without their PRs
+type TeamRepositoryNamesQuery struct {
+	Organization struct {
+		Team *struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+				Nodes []struct {
+					NameWithOwner string
+				}
+			} `graphql:"repositories(first: 100, after: $cursor)"`
+		} `graphql:"team(slug: $teamSlug)"`
+	} `graphql:"organization(login: $orgName)"`
+}
+
+// CheckContextNode is a check run or commit status in a statusCheckRollup
+type CheckContextNode struct {
+	Typename string `graphql:"__typename"`
+	CheckRun struct {
+		Name       string
+		Status     string // QUEUED, IN_PROGRESS, COMPLETED, ...
+		Conclusion string // SUCCESS, FAILURE, NEUTRAL, ... or null
+		DetailsURL string `graphql:"detailsUrl"`
+	} `graphql:"... on CheckRun"`
+	StatusContext struct {
+		Context   string
+		State     string // SUCCESS, FAILURE, ERROR, PENDING, EXPECTED
+		TargetURL string `graphql:"targetUrl"`
+	} `graphql:"... on StatusContext"`
+}
+
+// PullRequestDetailQuery represents the GraphQL query for the details of a single pull request
+type PullRequestDetailQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ChangedFiles int
+			Files        struct {
+				Nodes []struct {
+					Path      string
+					Additions int
+					Deletions int
+				}
+			} `graphql:"files(first: 100)"`
+			Reviews struct {
+				Nodes []struct {
+					Author struct {
+						Login string
+					}
+					State       string
+					Body        string
+					SubmittedAt *time.Time // null for pending reviews
+				}
+			} `graphql:"reviews(last: 20)"`
+			Comments struct {
+				TotalCount int
+				Nodes      []struct {
+					Author struct {
+						Login string
+					}
+					Body      string
+					CreatedAt time.Time
+				}
+			} `graphql:"comments(last: 20)"`
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts struct {
+								Nodes []CheckContextNode
+							} `graphql:"contexts(first: 100)"`
+						}
+					}
+				}
+			} `graphql:"commits(last: 1)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/shurcooL/graphql"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// searchResultLimit is the maximum number of results GitHub search returns for a query
+const searchResultLimit = 1000
+
+// FetchClosedPullRequests fetches bot PRs merged or closed since the given time
+// from the targets' repositories, applying the same repository and bot selection
+// as FetchPullRequests. The PR filter expression is not applied because it
+// mostly refers to open PR state (CI, mergeable). PRs are deduplicated across targets.
+func (c *Client) FetchClosedPullRequests(ctx context.Context, targets []models.Target, since time.Time) ([]models.ClosedPullRequest, error) {
+	var allPRs []models.ClosedPullRequest
+	seen := make(map[string]bool)
+
+	for _, target := range targets {
+		prs, err := c.fetchClosedTargetPullRequests(ctx, target, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch PR history for %s: %w", target.DisplayName(), err)
+		}
+		for _, pr := range prs {
+			key := fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+			if !seen[key] {
+				seen[key] = true
+				allPRs = append(allPRs, pr)
+			}
+		}
+	}
+
+	return allPRs, nil
+}
+
+// FetchClosedRepositoryPullRequests fetches bot PRs merged or closed since the given time from a specific repository
+func (c *Client) FetchClosedRepositoryPullRequests(ctx context.Context, owner, repo string, since time.Time) ([]models.ClosedPullRequest, error) {
+	return c.searchClosedPullRequests(ctx, fmt.Sprintf("repo:%s/%s", owner, repo), since, nil)
+}
+
+// fetchClosedTargetPullRequests fetches the PR history of a single target.
+// Search has no team qualifier, so team history is searched in the organization
+// and narrowed down to the team's repositories.
+func (c *Client) fetchClosedTargetPullRequests(ctx context.Context, target models.Target, since time.Time) ([]models.ClosedPullRequest, error) {
+	include := c.includeRepository
+	qualifier := "user:" + target.Name
+	if target.IsOrganization {
+		qualifier = "org:" + target.Name
+	}
+
+	if target.Team != "" {
+		teamRepos, err := c.fetchTeamRepositoryNames(ctx, target.Name, target.Team)
+		if err != nil {
+			return nil, err
+		}
+		include = func(repo RepositoryInfo) bool {
+			return teamRepos[repo.NameWithOwner] && c.includeRepository(repo)
+		}
+	}
+
+	return c.searchClosedPullRequests(ctx, qualifier, since, include)
+}
+
+// searchClosedPullRequests searches bot PRs merged or closed since the given time.
+// Each selected bot is searched separately; include (if non-nil) selects repositories.
+func (c *Client) searchClosedPullRequests(ctx context.Context, qualifier string, since time.Time, include func(RepositoryInfo) bool) ([]models.ClosedPullRequest, error) {
+	bots := make([]models.BotType, 0, len(models.BotLogins))
+	for botType := range models.BotLogins {
+		if len(c.bots) == 0 || c.bots[botType] {
+			bots = append(bots, botType)
+		}
+	}
+	sort.Slice(bots, func(i, j int) bool { return bots[i] < bots[j] })
+
+	var prs []models.ClosedPullRequest
+	for _, botType := range bots {
+		searchQuery := fmt.Sprintf("is:pr is:closed author:app/%s %s closed:>=%s",
+			botType, qualifier, since.UTC().Format("2006-01-02T15:04:05Z"))
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Searching PR history: %s\n", searchQuery)
+		}
+
+		var cursor *string
+		fetched := 0
+		for {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter error: %w", err)
+			}
+
+			var query ClosedPullRequestSearchQuery
+			variables := map[string]interface{}{
+				"query":  graphql.String(searchQuery),
+				"cursor": (*graphql.String)(cursor),
+			}
+			if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
+				return nil, fmt.Errorf("GraphQL query failed: %w", err)
+			}
+
+			for _, node := range query.Search.Nodes {
+				pr := node.PullRequest
+				fetched++
+				if include != nil && !include(pr.Repository) {
+					continue
+				}
+
+				// Search matches the app, but the author may still be another bot account
+				detected, isBot := models.DetectBot(pr.Author.Login)
+				if !isBot {
+					continue
+				}
+
+				prs = append(prs, models.ClosedPullRequest{
+					Repository: pr.Repository.NameWithOwner,
+					Number:     pr.Number,
+					Title:      pr.Title,
+					BotType:    detected,
+					CreatedAt:  pr.CreatedAt,
+					ClosedAt:   pr.ClosedAt,
+					Merged:     pr.Merged,
+				})
+			}
+
+			if !query.Search.PageInfo.HasNextPage {
+				break
+			}
+			if fetched >= searchResultLimit {
+				fmt.Fprintf(os.Stderr, "Warning: PR history search returned more than %d results; older PRs are not included (%s)\n",
+					searchResultLimit, searchQuery)
+				break
+			}
+			endCursor := query.Search.PageInfo.EndCursor
+			cursor = &endCursor
+		}
+	}
+
+	return prs, nil
+}
+
+// fetchTeamRepositoryNames returns the names (owner/repo) of a team's repositories
+func (c *Client) fetchTeamRepositoryNames(ctx context.Context, orgName, teamSlug string) (map[string]bool, error) {
+	names := make(map[string]bool)
+
+	var cursor *string
+	for {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter error: %w", err)
+		}
+
+		var query TeamRepositoryNamesQuery
+		variables := map[string]interface{}{
+			"orgName":  graphql.String(orgName),
+			"teamSlug": graphql.String(teamSlug),
+			"cursor":   (*graphql.String)(cursor),
+		}
+		if err := c.graphqlClient.Query(ctx, &query, variables); err != nil {
+			return nil, fmt.Errorf("GraphQL query failed: %w", err)
+		}
+		if query.Organization.Team == nil {
+			return nil, fmt.Errorf("team not found: %s/%s", orgName, teamSlug)
+		}
+
+		repos := query.Organization.Team.Repositories
+		for _, node := range repos.Nodes {
+			names[node.NameWithOwner] = true
+		}
+
+		if !repos.PageInfo.HasNextPage {
+			return names, nil
+		}
+		endCursor := repos.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+}
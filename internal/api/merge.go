@@ -11,11 +11,29 @@ import (
 	"strings"
 )
 
+// Merge methods supported by GitHub's merge API
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// ValidateMergeMethod returns an error if method is not a supported merge method
+func ValidateMergeMethod(method string) error {
+	switch method {
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+		return nil
+	default:
+		return fmt.Errorf("invalid merge method: %s (expected merge, squash, or rebase)", method)
+	}
+}
+
 // MergeRequest represents the request body for merging a PR
 type MergeRequest struct {
 	CommitTitle   string `json:"commit_title,omitempty"`
 	CommitMessage string `json:"commit_message,omitempty"`
 	MergeMethod   string `json:"merge_method"`
+	SHA           string `json:"sha,omitempty"` // Head commit the PR must still point at
 }
 
 // MergeResponse represents the response from GitHub's merge API
@@ -25,8 +43,10 @@ type MergeResponse struct {
 	Message string `json:"message"`
 }
 
-// MergePullRequest merges a PR using standard merge commit
-func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, prNumber int) (*MergeResponse, error) {
+// MergePullRequest merges a PR using the given merge method (merge, squash, or rebase).
+// A non-empty headSHA pins the head commit: GitHub rejects the merge (HTTP 409)
+// if commits were pushed after it.
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, prNumber int, method, headSHA string) (*MergeResponse, error) {
 	// Wait for rate limiter
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("rate limiter error: %w", err)
@@ -35,7 +55,8 @@ func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, prNum
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d/merge", owner, repo, prNumber)
 
 	reqBody := MergeRequest{
-		MergeMethod: "merge",
+		MergeMethod: method,
+		SHA:         headSHA,
 	}
 
 	bodyBytes, err := json.Marshal(reqBody)
@@ -51,7 +72,7 @@ func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, prNum
 	req.Header.Set("Content-Type", "application/json")
 
 	if c.verbose {
-		fmt.Fprintf(os.Stderr, "[DEBUG] Merging PR %s/%s#%d\n", owner, repo, prNumber)
+		fmt.Fprintf(os.Stderr, "[DEBUG] Merging PR %s/%s#%d (%s)\n", owner, repo, prNumber, method)
 	}
 
 	resp, err := c.httpClient.Do(req)
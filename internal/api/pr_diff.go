@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// diffMediaType requests a PR as a unified diff instead of JSON
+const diffMediaType = "application/vnd.github.v3.diff"
+
+// FetchPullRequestDiff fetches the unified diff of a PR
+func (c *Client) FetchPullRequestDiff(ctx context.Context, owner, repo string, prNumber int) (string, error) {
+	// Wait for rate limiter
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", restBaseURL, owner, repo, prNumber)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", diffMediaType)
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Fetching diff of PR %s/%s#%d\n", owner, repo, prNumber)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// GitHub responds with 406 when the diff is too large to generate
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("diff fetch failed (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// PullRequestState is the open/closed state of a PR
+type PullRequestState struct {
+	State  string `json:"state"`  // open or closed
+	Merged bool   `json:"merged"` // Whether the closed PR was merged
+}
+
+// FetchPullRequestState returns whether a PR is open, closed or merged
+func (c *Client) FetchPullRequestState(ctx context.Context, owner, repo string, prNumber int) (*PullRequestState, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	var state PullRequestState
+	if err := c.doREST(ctx, "GET", path, nil, &state, "fetching PR state failed"); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/pattern"
+)
+
+// Repository visibilities accepted by RepositoryFilter
+const (
+	VisibilityPublic   = "public"
+	VisibilityPrivate  = "private"
+	VisibilityInternal = "internal"
+)
+
+// RepositoryFilter narrows down the repositories scanned for a target.
+// Topic, language and visibility are sent to GitHub as repository search
+// qualifiers; every criterion is also checked against each returned repository.
+type RepositoryFilter struct {
+	Topics     []string         // Topics that must all be present
+	Language   string           // Primary language (case-insensitive)
+	Visibility string           // public, private, or internal
+	Name       *pattern.Pattern // Repository name glob or "re:" regex
+}
+
+// hasSearchQualifiers returns true if the filter can be expressed as search qualifiers
+func (f RepositoryFilter) hasSearchQualifiers() bool {
+	return len(f.Topics) > 0 || f.Language != "" || f.Visibility != ""
+}
+
+// searchQuery builds a repository search query for the given owner qualifier
+// (e.g., "org:my-org"). Forks are included and archived repositories excluded
+// to match the organization and user repository listings.
+func (f RepositoryFilter) searchQuery(ownerQualifier string) string {
+	terms := []string{ownerQualifier, "archived:false", "fork:true"}
+	for _, topic := range f.Topics {
+		terms = append(terms, "topic:"+topic)
+	}
+	if f.Language != "" {
+		terms = append(terms, "language:"+quoteSearchValue(f.Language))
+	}
+	if f.Visibility != "" {
+		terms = append(terms, "is:"+f.Visibility)
+	}
+	return strings.Join(terms, " ")
+}
+
+// Match reports whether a repository satisfies every criterion of the filter
+func (f RepositoryFilter) Match(repo RepositoryInfo) bool {
+	if f.Name != nil && !f.Name.Match(repo.Name) {
+		return false
+	}
+
+	if f.Visibility != "" && !strings.EqualFold(repo.Visibility, f.Visibility) {
+		return false
+	}
+
+	if f.Language != "" {
+		if repo.PrimaryLanguage == nil || !strings.EqualFold(repo.PrimaryLanguage.Name, f.Language) {
+			return false
+		}
+	}
+
+	for _, topic := range f.Topics {
+		found := false
+		for _, node := range repo.RepositoryTopics.Nodes {
+			if strings.EqualFold(node.Topic.Name, topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// quoteSearchValue quotes a search qualifier value containing spaces
+func quoteSearchValue(value string) string {
+	if strings.Contains(value, " ") {
+		return `"` + value + `"`
+	}
+	return value
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// WorkflowRun represents a GitHub Actions workflow run
+type WorkflowRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// workflowRunsResponse represents the response from the workflow runs API
+type workflowRunsResponse struct {
+	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+}
+
+// CheckSuite represents a check suite created by a GitHub App
+type CheckSuite struct {
+	ID         int64  `json:"id"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	App        struct {
+		Slug string `json:"slug"`
+	} `json:"app"`
+}
+
+// checkSuitesResponse represents the response from the check suites API
+type checkSuitesResponse struct {
+	CheckSuites []CheckSuite `json:"check_suites"`
+}
+
+// RerunResult summarizes what was re-run for a commit
+type RerunResult struct {
+	WorkflowRuns []string // Names of workflow runs whose failed jobs were re-run
+	CheckSuites  []string // App slugs of check suites that were re-requested
+}
+
+// Count returns the total number of re-run workflow runs and check suites
+func (r *RerunResult) Count() int {
+	return len(r.WorkflowRuns) + len(r.CheckSuites)
+}
+
+// githubActionsAppSlug is the app slug of check suites created by GitHub Actions
+const githubActionsAppSlug = "github-actions"
+
+// isFailedConclusion returns true if a completed run/suite conclusion should be retried
+func isFailedConclusion(conclusion string) bool {
+	switch strings.ToLower(conclusion) {
+	case "failure", "cancelled", "timed_out", "startup_failure", "action_required":
+		return true
+	default:
+		return false
+	}
+}
+
+// ListWorkflowRuns lists GitHub Actions workflow runs for a head commit SHA
+func (c *Client) ListWorkflowRuns(ctx context.Context, owner, repo, headSHA string) ([]WorkflowRun, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs?head_sha=%s&per_page=100", owner, repo, url.QueryEscape(headSHA))
+
+	var resp workflowRunsResponse
+	if err := c.doREST(ctx, "GET", path, nil, &resp, "listing workflow runs failed"); err != nil {
+		return nil, err
+	}
+
+	return resp.WorkflowRuns, nil
+}
+
+// ListCheckSuites lists the check suites for a commit SHA
+func (c *Client) ListCheckSuites(ctx context.Context, owner, repo, sha string) ([]CheckSuite, error) {
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/check-suites?per_page=100", owner, repo, url.PathEscape(sha))
+
+	var resp checkSuitesResponse
+	if err := c.doREST(ctx, "GET", path, nil, &resp, "listing check suites failed"); err != nil {
+		return nil, err
+	}
+
+	return resp.CheckSuites, nil
+}
+
+// RerunFailedJobs re-runs only the failed jobs of a workflow run
+func (c *Client) RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d/rerun-failed-jobs", owner, repo, runID)
+	return c.doREST(ctx, "POST", path, nil, nil, "rerun failed")
+}
+
+// RerequestCheckSuite asks the owning GitHub App to re-run a check suite
+func (c *Client) RerequestCheckSuite(ctx context.Context, owner, repo string, suiteID int64) error {
+	path := fmt.Sprintf("/repos/%s/%s/check-suites/%d/rerequest", owner, repo, suiteID)
+	return c.doREST(ctx, "POST", path, nil, nil, "check suite rerequest failed")
+}
+
+// RerunFailedChecks re-runs the failed CI for a PR head commit.
+// Failed GitHub Actions workflow runs have their failed jobs re-run, and failed
+// check suites from other GitHub Apps are re-requested. GitHub Actions check
+// suites are covered by their workflow runs and are not re-requested separately.
+func (c *Client) RerunFailedChecks(ctx context.Context, owner, repo, headSHA string) (*RerunResult, error) {
+	if headSHA == "" {
+		return nil, fmt.Errorf("head commit SHA is unknown")
+	}
+
+	result := &RerunResult{}
+
+	runs, err := c.ListWorkflowRuns(ctx, owner, repo, headSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, run := range runs {
+		if !strings.EqualFold(run.Status, "completed") || !isFailedConclusion(run.Conclusion) {
+			continue
+		}
+
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Re-running failed jobs of workflow run %s (%d) in %s/%s\n", run.Name, run.ID, owner, repo)
+		}
+
+		if err := c.RerunFailedJobs(ctx, owner, repo, run.ID); err != nil {
+			return result, fmt.Errorf("failed to re-run workflow %s: %w", run.Name, err)
+		}
+		result.WorkflowRuns = append(result.WorkflowRuns, run.Name)
+	}
+
+	suites, err := c.ListCheckSuites(ctx, owner, repo, headSHA)
+	if err != nil {
+		return result, err
+	}
+
+	for _, suite := range suites {
+		if suite.App.Slug == githubActionsAppSlug {
+			continue
+		}
+		if !strings.EqualFold(suite.Status, "completed") || !isFailedConclusion(suite.Conclusion) {
+			continue
+		}
+
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Re-requesting check suite %d (%s) in %s/%s\n", suite.ID, suite.App.Slug, owner, repo)
+		}
+
+		if err := c.RerequestCheckSuite(ctx, owner, repo, suite.ID); err != nil {
+			return result, fmt.Errorf("failed to re-request check suite from %s: %w", suite.App.Slug, err)
+		}
+		result.CheckSuites = append(result.CheckSuites, suite.App.Slug)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Review events supported by GitHub's review API
+const (
+	ReviewEventApprove = "APPROVE"
+)
+
+// ReviewRequest represents the request body for creating a PR review
+type ReviewRequest struct {
+	Event string `json:"event"`
+	Body  string `json:"body,omitempty"`
+}
+
+// ReviewResponse represents the response from GitHub's review API
+type ReviewResponse struct {
+	ID    int64  `json:"id"`
+	State string `json:"state"`
+	URL   string `json:"html_url"`
+}
+
+// ApprovePullRequest submits an approving review on a PR
+func (c *Client) ApprovePullRequest(ctx context.Context, owner, repo string, prNumber int) (*ReviewResponse, error) {
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Approving PR %s/%s#%d\n", owner, repo, prNumber)
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", owner, repo, prNumber)
+	var review ReviewResponse
+	if err := c.doREST(ctx, "POST", path, ReviewRequest{Event: ReviewEventApprove}, &review, "approval failed"); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// DismissReviewRequest represents the request body for dismissing a PR review
+type DismissReviewRequest struct {
+	Message string `json:"message"`
+}
+
+// DismissReview dismisses a review on a PR (e.g. to withdraw an approval)
+func (c *Client) DismissReview(ctx context.Context, owner, repo string, prNumber int, reviewID int64, message string) error {
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Dismissing review %d on PR %s/%s#%d\n", reviewID, owner, repo, prNumber)
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews/%d/dismissals", owner, repo, prNumber, reviewID)
+	return c.doREST(ctx, "PUT", path, DismissReviewRequest{Message: message}, nil, "review dismissal failed")
+}
@@ -22,6 +22,16 @@ type UpdatePRResponse struct {
 	Body   string `json:"body"`
 }
 
+// FetchPullRequestBody returns the current body of a PR
+func (c *Client) FetchPullRequestBody(ctx context.Context, owner, repo string, prNumber int) (string, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	var pr UpdatePRResponse
+	if err := c.doREST(ctx, "GET", path, nil, &pr, "fetching PR body failed"); err != nil {
+		return "", err
+	}
+	return pr.Body, nil
+}
+
 // UpdatePullRequestBody updates the body of a PR
 func (c *Client) UpdatePullRequestBody(ctx context.Context, owner, repo string, prNumber int, body string) (*UpdatePRResponse, error) {
 	// Wait for rate limiter
@@ -3,8 +3,13 @@ package app
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/audit"
 	"github.com/swfz/gh-deps/internal/formatter"
 	"github.com/swfz/gh-deps/internal/interactive"
 	"github.com/swfz/gh-deps/internal/models"
@@ -12,60 +17,75 @@ import (
 
 // App encapsulates the application logic
 type App struct {
-	client *api.Client
-	config *Config
+	client  *api.Client
+	fetcher *api.Fetcher
+	config  *Config
 }
 
 // New creates a new application instance
 func New(config *Config) (*App, error) {
-	client, err := api.NewClient(config.Verbose, config.SkipChecks, config.ExcludeRepositories, config.Target, config.IsOrganization)
+	client, err := api.NewClient(api.ClientOptions{
+		Verbose:             config.Verbose,
+		SkipChecks:          config.SkipChecks,
+		ExcludeRepositories: config.ExcludeRepositories,
+		IncludePatterns:     config.RepositoryPatterns,
+		Targets:             config.Targets,
+		Bots:                config.Bots,
+		RepositoryFilter:    config.RepositoryFilter,
+		Filter:              config.Filter,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
+	// Status symbols are shared by every output
+	formatter.SetASCII(config.ASCII)
+
+	// Progress messages are only shown in verbose mode
+	var progress io.Writer
+	if config.Verbose {
+		progress = os.Stdout
+	}
+	fetcher := api.NewFetcher(client, api.FetchSpec{
+		Targets:      config.Targets,
+		Repositories: config.Repositories,
+		Patterns:     config.RepositoryPatterns,
+		Limit:        config.Limit,
+	}, progress)
+
 	return &App{
-		client: client,
-		config: config,
+		client:  client,
+		fetcher: fetcher,
+		config:  config,
 	}, nil
 }
 
 // Run executes the main application logic
 func (a *App) Run(ctx context.Context) error {
-	var prs []models.PullRequest
-	var err error
-
-	// Fetch PRs based on mode
-	if len(a.config.Repositories) > 0 {
-		// Fetch PRs from specific repositories
-		if a.config.Verbose {
-			fmt.Printf("Fetching dependency PRs from specific repositories: %v\n", a.config.Repositories)
-		}
-		prs, err = a.fetchSpecificRepositories(ctx)
-	} else if a.config.IsOrganization {
-		if a.config.Verbose {
-			limitMsg := "all PRs"
-			if a.config.Limit > 0 {
-				limitMsg = fmt.Sprintf("up to %d PRs", a.config.Limit)
-			}
-			fmt.Printf("Fetching dependency PRs from organization: %s (%s)\n",
-				a.config.Target, limitMsg)
-		}
-		prs, err = a.client.FetchOrgPullRequests(ctx, a.config.Target, a.config.Limit)
-	} else {
-		if a.config.Verbose {
-			limitMsg := "all PRs"
-			if a.config.Limit > 0 {
-				limitMsg = fmt.Sprintf("up to %d PRs", a.config.Limit)
-			}
-			fmt.Printf("Fetching dependency PRs from user: %s (%s)\n",
-				a.config.Target, limitMsg)
-		}
-		prs, err = a.client.FetchUserPullRequests(ctx, a.config.Target, a.config.Limit)
+	// Watch and serve fetch repeatedly on their own schedule
+	switch a.config.Command {
+	case CommandWatch:
+		return a.runWatch(ctx)
+	case CommandServe:
+		return a.runServe(ctx)
 	}
 
+	prs, err := a.fetcher.Fetch(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch pull requests: %w", err)
 	}
+	fetchedAt := time.Now()
+
+	// Diff compares with the stored snapshots before saving this fetch
+	if a.config.Command == CommandDiff {
+		return a.runDiff(prs, fetchedAt)
+	}
+	a.saveSnapshot(prs, fetchedAt)
+
+	// Stats also reports history when nothing is open
+	if a.config.Command == CommandStats {
+		return a.runStats(ctx, prs, fetchedAt)
+	}
 
 	// Handle empty results
 	if len(prs) == 0 {
@@ -73,8 +93,31 @@ func (a *App) Run(ctx context.Context) error {
 		return nil
 	}
 
+	switch a.config.Command {
+	case CommandRerun:
+		return a.runRerun(ctx, prs)
+	case CommandRepos:
+		return a.runRepos(prs, fetchedAt)
+	case CommandAutomerge:
+		return a.runAutomerge(ctx, prs, fetchedAt)
+	default:
+		return a.runList(ctx, prs)
+	}
+}
+
+// runList renders the PR table and optionally enters interactive mode
+func (a *App) runList(ctx context.Context, prs []models.PullRequest) error {
+	if a.config.Format == FormatJSON {
+		return formatter.RenderJSON(os.Stdout, prs, a.config.SortKeys)
+	}
+
 	// Render table (with row numbers if interactive mode)
-	sortedPRs := formatter.RenderTable(prs, a.config.Interactive)
+	sortedPRs := formatter.RenderTable(os.Stdout, prs, formatter.TableOptions{
+		ShowRowNumbers: a.config.Interactive,
+		SortKeys:       a.config.SortKeys,
+		Columns:        a.config.Columns,
+		Width:          terminalWidth(),
+	})
 
 	// Print summary with indicators
 	fmt.Printf("\nTotal: %d dependency update PRs", len(prs))
@@ -88,7 +131,18 @@ func (a *App) Run(ctx context.Context) error {
 
 	// Enter interactive mode if flag is set
 	if a.config.Interactive {
-		if err := interactive.RunTUI(ctx, sortedPRs, a.client, a.config.Target, a.config.IsOrganization, a.config.Limit, a.config.Verbose); err != nil {
+		opts := interactive.Options{
+			Fetcher:     a.fetcher.Silent(), // Progress output would corrupt the TUI
+			Verbose:     a.config.Verbose,
+			MergeMethod: a.config.MergeMethod,
+			Keymap:      a.config.Keymap,
+			Theme:       a.config.Theme,
+			ASCII:       a.config.ASCII,
+			Keybindings: a.config.Keybindings,
+			SortKeys:    a.config.SortKeys,
+			AuditLog:    auditLog(),
+		}
+		if err := interactive.RunTUI(ctx, sortedPRs, a.client, opts); err != nil {
 			return fmt.Errorf("interactive mode failed: %w", err)
 		}
 	}
@@ -96,34 +150,26 @@ func (a *App) Run(ctx context.Context) error {
 	return nil
 }
 
-// fetchSpecificRepositories fetches PRs from the repositories specified by --repo.
-// Note: archived repositories are not filtered here because explicitly specifying
-// a repository via --repo is treated as an intentional user choice.
-func (a *App) fetchSpecificRepositories(ctx context.Context) ([]models.PullRequest, error) {
-	var allPRs []models.PullRequest
-
-	for _, repo := range a.config.Repositories {
-		owner, name, err := api.ParseRepository(repo)
-		if err != nil {
-			// Short format (reponame): use target as owner
-			owner = a.config.Target
-			name = repo
-		}
-
-		if a.config.Verbose {
-			fmt.Printf("Fetching dependency PRs from repository: %s/%s\n", owner, name)
-		}
-
-		prs, err := a.client.FetchRepositoryPullRequests(ctx, owner, name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch PRs from %s/%s: %w", owner, name, err)
-		}
-		allPRs = append(allPRs, prs...)
-
-		if a.config.Limit > 0 && len(allPRs) >= a.config.Limit {
-			return allPRs[:a.config.Limit], nil
-		}
+// terminalWidth returns the width of the terminal, or 0 when output is not a terminal
+func terminalWidth() int {
+	t := term.FromEnv()
+	if !t.IsTerminalOutput() {
+		return 0
+	}
+	width, _, err := t.Size()
+	if err != nil {
+		return 0
 	}
+	return width
+}
 
-	return allPRs, nil
+// auditLog returns the audit log of interactive mode actions in the state directory.
+// Without a state directory, actions are only listed in the TUI.
+func auditLog() *audit.Log {
+	path, err := audit.DefaultPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: audit log disabled: %v\n", err)
+		return nil
+	}
+	return audit.New(path)
 }
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/policy"
+	"github.com/swfz/gh-deps/internal/sorter"
+)
+
+// runAutomerge evaluates every PR against the policy rules, prints the decisions
+// and merges the PRs that a rule allows (unless --dry-run is given)
+func (a *App) runAutomerge(ctx context.Context, prs []models.PullRequest, now time.Time) error {
+	sorter.Sort(prs, a.config.SortKeys)
+	decisions := a.config.Policy.Evaluate(prs, now)
+
+	var mergeCount, errCount int
+	for _, d := range decisions {
+		if d.Action == policy.ActionSkip {
+			fmt.Printf("- %s#%d: skip%s: %s\n", d.PR.Repository, d.PR.Number, formatRuleName(d.Rule), d.Reason)
+			continue
+		}
+
+		mergeCount++
+		if a.config.DryRun {
+			fmt.Printf("%s %s#%d: would %s%s: %s\n", formatter.Symbols().Done, d.PR.Repository, d.PR.Number, d.MergeMethod, formatRuleName(d.Rule), d.Reason)
+			continue
+		}
+
+		owner, repo, err := api.ParseRepository(d.PR.Repository)
+		if err != nil {
+			return err
+		}
+		// Pin the evaluated head commit so that commits pushed since the fetch are never merged unchecked
+		resp, err := a.client.MergePullRequest(ctx, owner, repo, d.PR.Number, d.MergeMethod, d.PR.HeadSHA)
+		if err == nil && !resp.Merged {
+			err = fmt.Errorf("merge unsuccessful: %s", resp.Message)
+		}
+		if err != nil {
+			errCount++
+			fmt.Printf("%s %s#%d: %s failed%s: %v\n", formatter.Symbols().Failed, d.PR.Repository, d.PR.Number, d.MergeMethod, formatRuleName(d.Rule), err)
+			continue
+		}
+		fmt.Printf("%s %s#%d: merged (%s)%s: %s\n", formatter.Symbols().Done, d.PR.Repository, d.PR.Number, d.MergeMethod, formatRuleName(d.Rule), d.Reason)
+	}
+
+	fmt.Printf("\nTotal: %d dependency update PRs, %d to merge, %d skipped", len(decisions), mergeCount, len(decisions)-mergeCount)
+	if a.config.DryRun {
+		fmt.Printf(" [dry run]")
+	}
+	if errCount > 0 {
+		fmt.Printf(" (%d failed)", errCount)
+	}
+	fmt.Println()
+
+	if errCount > 0 {
+		return fmt.Errorf("failed to merge %d PRs", errCount)
+	}
+	return nil
+}
+
+// formatRuleName formats the deciding rule for a decision line
+func formatRuleName(rule string) string {
+	if rule == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", rule)
+}
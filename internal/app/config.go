@@ -3,29 +3,85 @@ package app
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"os"
 	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/filter"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/interactive"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/notify"
+	"github.com/swfz/gh-deps/internal/pattern"
+	"github.com/swfz/gh-deps/internal/policy"
+	"github.com/swfz/gh-deps/internal/sorter"
+)
+
+// Subcommands supported by gh-deps
+const (
+	CommandList      = ""          // Default: list dependency PRs
+	CommandRerun     = "rerun"     // Re-run failed CI checks of the listed PRs
+	CommandWatch     = "watch"     // Refresh the table continuously
+	CommandDiff      = "diff"      // Report changes since a previous snapshot
+	CommandStats     = "stats"     // Report PR aging and merge throughput
+	CommandRepos     = "repos"     // Rank repositories by dependency health
+	CommandServe     = "serve"     // Expose metrics over HTTP
+	CommandAutomerge = "automerge" // Merge PRs allowed by the policy rules
+)
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Target             string   // Organization or user name
-	IsOrganization     bool     // True if targeting an organization, false for user
-	Verbose            bool     // Enable verbose output
-	Limit              int      // Maximum PRs to display (0 = unlimited)
-	SkipChecks         bool     // Skip fetching check runs
-	Interactive        bool     // Enable interactive PR merge mode
-	ExcludeRepositories []string // Repositories to exclude (comma-separated list)
-	Repositories       []string // Specific repositories to include (comma-separated list)
+	Command             string               // Subcommand to run (empty for listing)
+	Profile             string               // Selected config file profile
+	Targets             []models.Target      // Organizations and users to scan
+	Verbose             bool                 // Enable verbose output
+	Limit               int                  // Maximum PRs to display (0 = unlimited)
+	SkipChecks          bool                 // Skip fetching check runs
+	Interactive         bool                 // Enable interactive PR merge mode
+	ExcludeRepositories []string             // Repositories or patterns to exclude (comma-separated list)
+	Repositories        []string             // Specific repositories to include (comma-separated list)
+	RepositoryPatterns  []string             // Glob/regex patterns from --repo matched against target repositories
+	Bots                []models.BotType     // Bot types to include (empty = all)
+	RepositoryFilter    api.RepositoryFilter // Topic/language/visibility/name filter
+	Filter              *filter.Filter       // PR filter expression (nil = all)
+	SortKeys            []sorter.Key         // Sort keys for the table, JSON output and interactive mode
+	Columns             []formatter.Column   // Table columns in display order
+	Format              string               // Output format (table, json)
+	MergeMethod         string               // Merge method (merge, squash, rebase)
+	Keymap              string               // Keymap preset of interactive mode (config file only)
+	Theme               string               // Color theme of interactive mode (dark, light, high-contrast)
+	ASCII               bool                 // Replace emoji and symbols with ASCII in all outputs
+	Keybindings         map[string][]string  // Extra interactive keys per action (config file only)
+	Interval            time.Duration        // Refresh interval for the watch and serve commands
+	MetricsAddr         string               // Listen address of the serve command
+	Since               string               // Baseline of the diff command / history period of stats (age, date or RFC 3339 time)
+	NoSnapshot          bool                 // Do not save fetched PRs to the snapshot store
+	Notifiers           notify.Multi         // Notifiers used by the watch and serve commands
+	NotifyEvents        []notify.EventType   // Events to notify about (empty = all)
+	Policy              *policy.Policy       // Automerge policy rules (automerge command only)
+	DryRun              bool                 // Print automerge decisions without merging
 }
 
-// ParseConfig parses command-line flags and validates configuration
+// ParseConfig parses command-line flags and the config file, and validates configuration.
+// Settings are resolved as: explicit flags > selected profile > flag defaults.
 func ParseConfig() (*Config, error) {
-	var org, user, exclude, repo string
+	var org, user, team, exclude, repo, bot, topic, name, filterExpr, sortSpec, columns string
+	var notifiers, notifyOn, policyFile string
 
-	flag.StringVar(&org, "org", "", "GitHub organization name")
-	flag.StringVar(&user, "user", "", "GitHub user name")
+	flag.StringVar(&org, "org", "", "Comma-separated list of GitHub organization names")
+	flag.StringVar(&user, "user", "", "Comma-separated list of GitHub user names")
+	flag.StringVar(&team, "team", "", "Comma-separated list of GitHub teams (e.g., my-org/platform)")
 
 	config := &Config{}
+	flag.StringVar(&config.Profile, "profile", "", "Config file profile to use")
 	flag.BoolVar(&config.Verbose, "verbose", false, "Enable verbose output")
 	flag.BoolVar(&config.Verbose, "v", false, "Enable verbose output (shorthand)")
 	flag.IntVar(&config.Limit, "limit", 50, "Limit number of PRs to display (0 = unlimited)")
@@ -33,18 +89,134 @@ func ParseConfig() (*Config, error) {
 	flag.BoolVar(&config.SkipChecks, "skip-checks", false, "Skip fetching CI check runs")
 	flag.BoolVar(&config.Interactive, "interactive", false, "Enable interactive PR merge mode")
 	flag.BoolVar(&config.Interactive, "i", false, "Enable interactive mode (shorthand)")
-	flag.StringVar(&exclude, "exclude", "", "Comma-separated list of repositories to exclude (e.g., owner/repo1,owner/repo2)")
-	flag.StringVar(&repo, "repo", "", "Comma-separated list of specific repositories to check (e.g., owner/repo1,owner/repo2)")
+	flag.StringVar(&exclude, "exclude", "", "Comma-separated list of repositories or patterns to exclude (e.g., owner/repo1,legacy-*,re:^tmp-)")
+	flag.StringVar(&repo, "repo", "", "Comma-separated list of repositories or patterns to check (e.g., owner/repo1,svc-*,re:^api-)")
+	flag.StringVar(&topic, "topic", "", "Comma-separated list of repository topics that must all be present")
+	flag.StringVar(&config.RepositoryFilter.Language, "language", "", "Repository primary language")
+	flag.StringVar(&config.RepositoryFilter.Visibility, "visibility", "", "Repository visibility (public, private, internal)")
+	flag.StringVar(&name, "name", "", "Repository name glob (e.g., svc-*) or regex with re: prefix (e.g., re:^svc-)")
+	flag.StringVar(&filterExpr, "filter", "", "Filter expression (e.g., 'bot:renovate ci:success age>7d -repo:legacy')")
+	flag.StringVar(&sortSpec, "sort", "", "Comma-separated sort keys with optional :asc/:desc (age, repo, bot, ci, mergeable, update-type, dependency, number)")
+	flag.StringVar(&columns, "columns", "", "Comma-separated table columns in display order (e.g., repo,number,dependency,version,ci,age,url)")
+	flag.StringVar(&bot, "bot", "", "Comma-separated list of bots to include (renovate, dependabot, github-actions)")
+	flag.StringVar(&config.Format, "format", FormatTable, "Output format (table, json)")
+	flag.DurationVar(&config.Interval, "interval", 5*time.Minute, "Refresh interval for the watch and serve commands (e.g., 5m)")
+	flag.StringVar(&config.MetricsAddr, "metrics", ":9090", "Listen address for the serve command's /metrics endpoint")
+	flag.StringVar(&config.Since, "since", "", "Baseline for diff / history period for stats: an age (e.g., 24h, 1d), a date (2006-01-02) or an RFC 3339 time")
+	flag.BoolVar(&config.NoSnapshot, "no-snapshot", false, "Do not save fetched PRs to the local snapshot store")
+	flag.StringVar(&notifiers, "notify", "", "Comma-separated notifiers for the watch and serve commands (webhook=URL, slack=URL, bell)")
+	flag.StringVar(&notifyOn, "notify-on", "", "Comma-separated events to notify about (new_pr, ci_failed, conflicting; default: all)")
+	flag.StringVar(&policyFile, "policy", "", "Automerge policy file (default: "+DefaultPolicyFilePath()+")")
+	flag.BoolVar(&config.DryRun, "dry-run", false, "Print automerge decisions without merging")
+	flag.StringVar(&config.MergeMethod, "merge-method", api.MergeMethodMerge, "Merge method for interactive mode (merge, squash, rebase)")
+	flag.StringVar(&config.Theme, "theme", "dark", "Color theme for interactive mode (dark, light, high-contrast)")
+	flag.BoolVar(&config.ASCII, "ascii", false, "Replace emoji and symbols with ASCII words (e.g., pass/fail/pend)")
+
+	// The first non-flag argument selects the subcommand
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		config.Command = args[0]
+		args = args[1:]
+	}
+	switch config.Command {
+	case CommandList, CommandRerun, CommandWatch, CommandDiff, CommandStats, CommandRepos, CommandServe, CommandAutomerge:
+	default:
+		return nil, fmt.Errorf("unknown command: %s", config.Command)
+	}
+
+	if err := flag.CommandLine.Parse(args); err != nil {
+		return nil, err
+	}
 
-	flag.Parse()
+	// Collect explicitly specified flags so that they override the profile
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
 
-	// Validate that exactly one of org or user is specified
-	if org == "" && user == "" {
-		return nil, errors.New("either --org or --user must be specified")
+	fileConfig, err := LoadFileConfig(ConfigFilePaths())
+	if err != nil {
+		return nil, err
+	}
+	profile, err := fileConfig.SelectProfile(config.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply profile values for settings not given on the command line
+	if !explicit["org"] && !explicit["user"] && !explicit["team"] {
+		org, user = strings.Join(profile.Org, ","), strings.Join(profile.User, ",")
+		team = strings.Join(profile.Team, ",")
+	}
+	if !explicit["topic"] && profile.Topics != nil {
+		topic = strings.Join(profile.Topics, ",")
+	}
+	if !explicit["language"] && profile.Language != "" {
+		config.RepositoryFilter.Language = profile.Language
+	}
+	if !explicit["visibility"] && profile.Visibility != "" {
+		config.RepositoryFilter.Visibility = profile.Visibility
+	}
+	if !explicit["name"] && profile.Name != "" {
+		name = profile.Name
+	}
+	if !explicit["exclude"] && profile.Exclude != nil {
+		exclude = strings.Join(profile.Exclude, ",")
+	}
+	if !explicit["repo"] && profile.Repos != nil {
+		repo = strings.Join(profile.Repos, ",")
+	}
+	if !explicit["bot"] && profile.Bots != nil {
+		bot = strings.Join(profile.Bots, ",")
+	}
+	if !explicit["filter"] && profile.Filter != "" {
+		filterExpr = profile.Filter
+	}
+	if !explicit["sort"] && profile.Sort != "" {
+		sortSpec = profile.Sort
+	}
+	if !explicit["columns"] && profile.Columns != nil {
+		columns = strings.Join(profile.Columns, ",")
 	}
+	if !explicit["limit"] && !explicit["l"] && profile.Limit != nil {
+		config.Limit = *profile.Limit
+	}
+	if !explicit["skip-checks"] && profile.SkipChecks != nil {
+		config.SkipChecks = *profile.SkipChecks
+	}
+	if !explicit["format"] && profile.Format != "" {
+		config.Format = profile.Format
+	}
+	if !explicit["merge-method"] && profile.MergeMethod != "" {
+		config.MergeMethod = profile.MergeMethod
+	}
+	if !explicit["theme"] && profile.Theme != "" {
+		config.Theme = profile.Theme
+	}
+	if !explicit["ascii"] && profile.ASCII != nil {
+		config.ASCII = *profile.ASCII
+	}
+	if !explicit["interval"] && profile.Interval != "" {
+		config.Interval, err = time.ParseDuration(profile.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval in config file: %w", err)
+		}
+	}
+	if !explicit["policy"] && profile.Policy != "" {
+		policyFile = profile.Policy
+	}
+	if !explicit["notify"] && profile.Notify != nil {
+		notifiers = strings.Join(profile.Notify, ",")
+	}
+	if !explicit["notify-on"] && profile.NotifyOn != nil {
+		notifyOn = strings.Join(profile.NotifyOn, ",")
+	}
+	config.Keymap = profile.Keymap
+	config.Keybindings = profile.Keybindings
 
-	if org != "" && user != "" {
-		return nil, errors.New("cannot specify both --org and --user")
+	// Validate that at least one org, team or user is specified
+	if org == "" && user == "" && team == "" {
+		return nil, errors.New("either --org, --team or --user must be specified")
 	}
 
 	// Validate limit
@@ -52,22 +224,187 @@ func ParseConfig() (*Config, error) {
 		return nil, errors.New("--limit must be >= 0")
 	}
 
-	// Set target and type
-	if org != "" {
-		config.Target = org
-		config.IsOrganization = true
-	} else {
-		config.Target = user
-		config.IsOrganization = false
+	// Set targets, skipping duplicates
+	seen := make(map[models.Target]bool)
+	var targets []models.Target
+	for _, name := range splitCSV(org) {
+		targets = append(targets, models.Target{Name: name, IsOrganization: true})
+	}
+	for _, spec := range splitCSV(team) {
+		orgName, slug, ok := strings.Cut(spec, "/")
+		if !ok || orgName == "" || slug == "" || strings.Contains(slug, "/") {
+			return nil, fmt.Errorf("invalid team: %s (expected org/team)", spec)
+		}
+		targets = append(targets, models.Target{Name: orgName, IsOrganization: true, Team: slug})
+	}
+	for _, name := range splitCSV(user) {
+		targets = append(targets, models.Target{Name: name, IsOrganization: false})
+	}
+	for _, target := range targets {
+		if !seen[target] {
+			seen[target] = true
+			config.Targets = append(config.Targets, target)
+		}
 	}
 
-	// Validate that --exclude and --repo are not both specified
-	if exclude != "" && repo != "" {
-		return nil, errors.New("cannot specify both --exclude and --repo")
+	// Repository filters
+	config.RepositoryFilter.Topics = splitCSV(topic)
+	config.RepositoryFilter.Visibility = strings.ToLower(config.RepositoryFilter.Visibility)
+	switch config.RepositoryFilter.Visibility {
+	case "", api.VisibilityPublic, api.VisibilityPrivate, api.VisibilityInternal:
+	default:
+		return nil, fmt.Errorf("invalid visibility: %s (expected public, private, or internal)", config.RepositoryFilter.Visibility)
+	}
+	if name != "" {
+		namePattern, err := pattern.Compile(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --name: %w", err)
+		}
+		config.RepositoryFilter.Name = namePattern
 	}
 
+	// Repositories and exclusions may be literal names or glob/regex patterns
 	config.ExcludeRepositories = splitCSV(exclude)
-	config.Repositories = splitCSV(repo)
+	for _, r := range splitCSV(repo) {
+		if pattern.IsPattern(r) {
+			config.RepositoryPatterns = append(config.RepositoryPatterns, r)
+		} else {
+			config.Repositories = append(config.Repositories, r)
+		}
+	}
+	for _, p := range append(config.RepositoryPatterns, config.ExcludeRepositories...) {
+		if _, err := pattern.Compile(p); err != nil {
+			return nil, fmt.Errorf("invalid repository pattern: %w", err)
+		}
+	}
+
+	// Short repository names are resolved against the target, which is ambiguous with several
+	if len(config.Targets) > 1 {
+		for _, r := range config.Repositories {
+			if !strings.Contains(r, "/") {
+				return nil, fmt.Errorf("--repo %s must be in owner/repo format when multiple targets are specified", r)
+			}
+		}
+	}
+
+	for _, name := range splitCSV(bot) {
+		botType, err := models.ParseBotType(name)
+		if err != nil {
+			return nil, err
+		}
+		config.Bots = append(config.Bots, botType)
+	}
+
+	if filterExpr != "" {
+		config.Filter, err = filter.Parse(filterExpr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --filter: %w", err)
+		}
+	}
+
+	config.SortKeys, err = sorter.ParseKeys(sortSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --sort: %w", err)
+	}
+
+	config.Columns, err = formatter.ParseColumns(columns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --columns: %w", err)
+	}
+
+	// Validate output format
+	if config.Format != FormatTable && config.Format != FormatJSON {
+		return nil, fmt.Errorf("invalid format: %s (expected table or json)", config.Format)
+	}
+	if config.Format == FormatJSON && config.Interactive {
+		return nil, errors.New("cannot use --format json with --interactive")
+	}
+
+	if err := api.ValidateMergeMethod(config.MergeMethod); err != nil {
+		return nil, err
+	}
+
+	if err := interactive.ValidateTheme(config.Theme); err != nil {
+		return nil, err
+	}
+	if err := interactive.ValidateKeymap(config.Keymap); err != nil {
+		return nil, err
+	}
+	if err := interactive.ValidateKeybindings(config.Keybindings); err != nil {
+		return nil, err
+	}
+
+	// Rerun decides what to re-run from the CI status
+	if config.Command == CommandRerun && config.SkipChecks {
+		return nil, errors.New("cannot use --skip-checks with the rerun command")
+	}
+
+	if config.Command == CommandWatch || config.Command == CommandServe {
+		if config.Interactive || config.Format == FormatJSON {
+			return nil, fmt.Errorf("cannot use --interactive or --format json with the %s command", config.Command)
+		}
+		if config.Interval < minWatchInterval {
+			return nil, fmt.Errorf("--interval must be at least %s", minWatchInterval)
+		}
+	}
+
+	for _, spec := range splitCSV(notifiers) {
+		notifier, err := notify.Parse(spec, os.Stdout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --notify: %w", err)
+		}
+		config.Notifiers = append(config.Notifiers, notifier)
+	}
+	config.NotifyEvents, err = notify.ParseEventTypes(notifyOn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --notify-on: %w", err)
+	}
+	// Notifications are only sent when a selection is fetched repeatedly.
+	// A profile may configure notifiers for every command, so only explicit flags are rejected.
+	if config.Command != CommandWatch && config.Command != CommandServe && (explicit["notify"] || explicit["notify-on"]) {
+		return nil, errors.New("--notify and --notify-on can only be used with the watch and serve commands")
+	}
+
+	switch config.Command {
+	case CommandDiff, CommandStats:
+		if config.Interactive {
+			return nil, fmt.Errorf("cannot use --interactive with the %s command", config.Command)
+		}
+		if config.Since != "" {
+			if _, err := parseSince(config.Since, time.Now()); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if config.Since != "" {
+			return nil, errors.New("--since can only be used with the diff and stats commands")
+		}
+	}
+
+	if config.Command == CommandAutomerge {
+		if config.Interactive || config.Format == FormatJSON {
+			return nil, errors.New("cannot use --interactive or --format json with the automerge command")
+		}
+		if policyFile == "" {
+			policyFile = DefaultPolicyFilePath()
+		}
+		config.Policy, err = policy.Load(policyFile, config.MergeMethod)
+		if err != nil {
+			return nil, err
+		}
+	} else if explicit["policy"] || config.DryRun {
+		return nil, errors.New("--policy and --dry-run can only be used with the automerge command")
+	}
+
+	// Stats, repos, serve and automerge cover every open PR unless a limit is given explicitly
+	if (config.Command == CommandStats || config.Command == CommandRepos || config.Command == CommandServe ||
+		config.Command == CommandAutomerge) &&
+		!explicit["limit"] && !explicit["l"] && profile.Limit == nil {
+		config.Limit = 0
+	}
+	if config.Command == CommandRepos && config.Interactive {
+		return nil, errors.New("cannot use --interactive with the repos command (use the Repositories tab in interactive mode)")
+	}
 
 	return config, nil
 }
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/diff"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/snapshot"
+)
+
+// runDiff reports how the fetched PRs differ from the previous snapshot,
+// or from the last snapshot taken at or before --since
+func (a *App) runDiff(prs []models.PullRequest, fetchedAt time.Time) error {
+	store, err := a.snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	var baseline *snapshot.Snapshot
+	if a.config.Since == "" {
+		baseline, err = store.Latest()
+	} else {
+		var since time.Time
+		since, err = parseSince(a.config.Since, fetchedAt)
+		if err != nil {
+			return err
+		}
+		baseline, err = store.Before(since)
+		if err == nil && baseline == nil {
+			// Nothing that old yet: compare with the oldest snapshot instead
+			baseline, err = store.Oldest()
+			if baseline != nil && a.config.Format != FormatJSON {
+				fmt.Printf("No snapshot at or before %s; using the oldest snapshot.\n", since.Local().Format("2006-01-02 15:04"))
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	a.saveSnapshot(prs, fetchedAt)
+
+	if baseline == nil {
+		if a.config.Format == FormatJSON {
+			return formatter.RenderDiffJSON(os.Stdout, nil, nil)
+		}
+		fmt.Printf("No previous snapshot found. Saved %d PRs; run diff again later to see changes.\n", len(prs))
+		return nil
+	}
+
+	changes := diff.Compare(baseline.PullRequests, prs)
+	if a.config.Format == FormatJSON {
+		return formatter.RenderDiffJSON(os.Stdout, &baseline.CreatedAt, changes)
+	}
+
+	fmt.Printf("Changes since %s (%d PRs %s %d PRs)\n",
+		baseline.CreatedAt.Local().Format("2006-01-02 15:04"), len(baseline.PullRequests), formatter.Symbols().Arrow, len(prs))
+	if len(changes) == 0 {
+		fmt.Println("\nNo changes.")
+		return nil
+	}
+	formatter.RenderDiff(os.Stdout, changes)
+	return nil
+}
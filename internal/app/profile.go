@@ -0,0 +1,235 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config file locations
+const (
+	configDirName     = "gh-deps"
+	configFileName    = "config.yml"
+	policyFileName    = "automerge.yml" // Automerge policy rules
+	localConfigFile   = ".gh-deps.yml"  // Repository-local overrides in the working directory
+	defaultConfigBase = ".config"       // Fallback when XDG_CONFIG_HOME is not set
+)
+
+// Profile holds a named set of settings from the config file.
+// Unset fields fall back to the command-line defaults, and explicitly
+// specified flags always take precedence over profile values.
+type Profile struct {
+	Org         stringList          `yaml:"org"`          // GitHub organization names
+	User        stringList          `yaml:"user"`         // GitHub user names
+	Team        stringList          `yaml:"team"`         // GitHub teams (org/team)
+	Topics      []string            `yaml:"topics"`       // Repository topics that must all be present
+	Language    string              `yaml:"language"`     // Repository primary language
+	Visibility  string              `yaml:"visibility"`   // Repository visibility
+	Name        string              `yaml:"name"`         // Repository name glob or "re:" regex
+	Repos       []string            `yaml:"repos"`        // Specific repositories to include
+	Exclude     []string            `yaml:"exclude"`      // Repositories to exclude
+	Bots        []string            `yaml:"bots"`         // Bot types to include (empty = all)
+	Filter      string              `yaml:"filter"`       // PR filter expression
+	Sort        string              `yaml:"sort"`         // Sort keys (e.g., "ci,age:desc")
+	Columns     stringList          `yaml:"columns"`      // Table columns in display order
+	Limit       *int                `yaml:"limit"`        // Maximum PRs to display (0 = unlimited)
+	SkipChecks  *bool               `yaml:"skip_checks"`  // Skip fetching check runs
+	Format      string              `yaml:"format"`       // Output format (table, json)
+	MergeMethod string              `yaml:"merge_method"` // Merge method (merge, squash, rebase)
+	Interval    string              `yaml:"interval"`     // Refresh interval for the watch and serve commands (e.g., 5m)
+	Policy      string              `yaml:"policy"`       // Automerge policy file
+	Notify      stringList          `yaml:"notify"`       // Notifiers for the watch and serve commands (webhook=URL, slack=URL, bell)
+	NotifyOn    stringList          `yaml:"notify_on"`    // Events to notify about (new_pr, ci_failed, conflicting)
+	Keymap      string              `yaml:"keymap"`       // Keymap preset of interactive mode (default, vim, emacs)
+	Theme       string              `yaml:"theme"`        // Color theme of interactive mode (dark, light, high-contrast)
+	ASCII       *bool               `yaml:"ascii"`        // Replace emoji and symbols with ASCII
+	Keybindings map[string][]string `yaml:"keybindings"`  // Extra keys per interactive action
+}
+
+// FileConfig represents the contents of a config file
+type FileConfig struct {
+	DefaultProfile string             `yaml:"default_profile"` // Profile used when --profile is omitted
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// ConfigFilePaths returns the config files to load, in increasing precedence:
+// the user config file followed by the repository-local override file
+func ConfigFilePaths() []string {
+	var paths []string
+	if dir := configDir(); dir != "" {
+		paths = append(paths, filepath.Join(dir, configFileName))
+	}
+	return append(paths, localConfigFile)
+}
+
+// DefaultPolicyFilePath returns the automerge policy file used when --policy is omitted
+func DefaultPolicyFilePath() string {
+	if dir := configDir(); dir != "" {
+		return filepath.Join(dir, policyFileName)
+	}
+	return policyFileName
+}
+
+// configDir returns the gh-deps config directory, or "" when the home directory is unknown
+func configDir() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configHome = filepath.Join(home, defaultConfigBase)
+	}
+	return filepath.Join(configHome, configDirName)
+}
+
+// LoadFileConfig loads and merges all config files that exist.
+// Profiles with the same name are merged field by field, with later files winning.
+func LoadFileConfig(paths []string) (*FileConfig, error) {
+	merged := &FileConfig{Profiles: make(map[string]Profile)}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+
+		var file FileConfig
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+
+		if file.DefaultProfile != "" {
+			merged.DefaultProfile = file.DefaultProfile
+		}
+		for name, profile := range file.Profiles {
+			merged.Profiles[name] = mergeProfiles(merged.Profiles[name], profile)
+		}
+	}
+
+	return merged, nil
+}
+
+// SelectProfile returns the named profile, or the default profile when name is empty.
+// An empty profile is returned when neither is configured.
+func (f *FileConfig) SelectProfile(name string) (Profile, error) {
+	if name == "" {
+		name = f.DefaultProfile
+		if name == "" {
+			return Profile{}, nil
+		}
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile not found: %s", name)
+	}
+	return profile, nil
+}
+
+// mergeProfiles overlays the fields set in override onto base
+func mergeProfiles(base, override Profile) Profile {
+	if override.Org != nil || override.User != nil || override.Team != nil {
+		// Targets in the override replace the base targets entirely
+		base.Org = override.Org
+		base.User = override.User
+		base.Team = override.Team
+	}
+	if override.Topics != nil {
+		base.Topics = override.Topics
+	}
+	if override.Language != "" {
+		base.Language = override.Language
+	}
+	if override.Visibility != "" {
+		base.Visibility = override.Visibility
+	}
+	if override.Name != "" {
+		base.Name = override.Name
+	}
+	if override.Repos != nil {
+		base.Repos = override.Repos
+	}
+	if override.Exclude != nil {
+		base.Exclude = override.Exclude
+	}
+	if override.Bots != nil {
+		base.Bots = override.Bots
+	}
+	if override.Filter != "" {
+		base.Filter = override.Filter
+	}
+	if override.Sort != "" {
+		base.Sort = override.Sort
+	}
+	if override.Columns != nil {
+		base.Columns = override.Columns
+	}
+	if override.Limit != nil {
+		base.Limit = override.Limit
+	}
+	if override.SkipChecks != nil {
+		base.SkipChecks = override.SkipChecks
+	}
+	if override.Format != "" {
+		base.Format = override.Format
+	}
+	if override.MergeMethod != "" {
+		base.MergeMethod = override.MergeMethod
+	}
+	if override.Interval != "" {
+		base.Interval = override.Interval
+	}
+	if override.Policy != "" {
+		base.Policy = override.Policy
+	}
+	if override.Notify != nil {
+		base.Notify = override.Notify
+	}
+	if override.NotifyOn != nil {
+		base.NotifyOn = override.NotifyOn
+	}
+	if override.Keymap != "" {
+		base.Keymap = override.Keymap
+	}
+	if override.Theme != "" {
+		base.Theme = override.Theme
+	}
+	if override.ASCII != nil {
+		base.ASCII = override.ASCII
+	}
+	if len(override.Keybindings) > 0 {
+		if base.Keybindings == nil {
+			base.Keybindings = make(map[string][]string)
+		}
+		for action, keys := range override.Keybindings {
+			base.Keybindings[action] = keys
+		}
+	}
+	return base
+}
+
+// stringList is a list of strings that may also be written as a single
+// comma-separated scalar (e.g., "org: a,b" or "org: [a, b]")
+type stringList []string
+
+// UnmarshalYAML accepts either a scalar or a sequence of strings
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = splitCSV(value.Value)
+		return nil
+	}
+
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/health"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// runRepos ranks repositories by dependency health score, worst first
+func (a *App) runRepos(prs []models.PullRequest, fetchedAt time.Time) error {
+	repos := health.Compute(prs, fetchedAt)
+	if a.config.Format == FormatJSON {
+		return formatter.RenderHealthJSON(os.Stdout, repos)
+	}
+
+	formatter.RenderHealth(os.Stdout, repos)
+
+	attention := 0
+	for _, h := range repos {
+		if h.NeedsAttention() {
+			attention++
+		}
+	}
+	fmt.Printf("\nTotal: %d repositories with dependency update PRs (%d need attention)\n", len(repos), attention)
+	return nil
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// runRerun re-runs the failed CI checks of every listed PR whose CI is failing
+func (a *App) runRerun(ctx context.Context, prs []models.PullRequest) error {
+	var failed []models.PullRequest
+	for _, pr := range prs {
+		if pr.CheckSummary.Status == models.StatusFailure {
+			failed = append(failed, pr)
+		}
+	}
+
+	if len(failed) == 0 {
+		fmt.Println("No dependency update PRs with failing checks found.")
+		return nil
+	}
+
+	var errCount int
+	for _, pr := range failed {
+		owner, repo, err := api.ParseRepository(pr.Repository)
+		if err != nil {
+			return err
+		}
+
+		result, err := a.client.RerunFailedChecks(ctx, owner, repo, pr.HeadSHA)
+		if err != nil {
+			errCount++
+			fmt.Printf("%s %s#%d: %v\n", formatter.Symbols().Failed, pr.Repository, pr.Number, err)
+			continue
+		}
+
+		if result.Count() == 0 {
+			fmt.Printf("- %s#%d: nothing to re-run\n", pr.Repository, pr.Number)
+			continue
+		}
+
+		fmt.Printf("%s %s#%d: re-ran %s\n", formatter.Symbols().Done, pr.Repository, pr.Number, formatRerunResult(result))
+	}
+
+	fmt.Printf("\nTotal: %d PRs with failing checks", len(failed))
+	if errCount > 0 {
+		fmt.Printf(" (%d failed)", errCount)
+	}
+	fmt.Println()
+
+	if errCount > 0 {
+		return fmt.Errorf("failed to re-run checks for %d PRs", errCount)
+	}
+	return nil
+}
+
+// formatRerunResult describes which workflow runs and check suites were re-run
+func formatRerunResult(result *api.RerunResult) string {
+	var parts []string
+	if len(result.WorkflowRuns) > 0 {
+		parts = append(parts, "workflows: "+strings.Join(result.WorkflowRuns, ", "))
+	}
+	if len(result.CheckSuites) > 0 {
+		parts = append(parts, "check suites: "+strings.Join(result.CheckSuites, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
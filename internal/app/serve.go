@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/diff"
+	"github.com/swfz/gh-deps/internal/metrics"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// serverShutdownTimeout bounds how long in-flight scrapes may take on shutdown
+const serverShutdownTimeout = 5 * time.Second
+
+// runServe fetches PRs every interval and exposes them as metrics over HTTP
+func (a *App) runServe(ctx context.Context) error {
+	collector := metrics.NewCollector()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "gh-deps metrics exporter: see /metrics")
+	})
+
+	listener, err := net.Listen("tcp", a.config.MetricsAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", a.config.MetricsAddr, err)
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	fmt.Printf("Serving metrics on http://%s/metrics (refresh every %s)\n", listener.Addr(), a.config.Interval)
+
+	state := &watchState{remaining: -1}
+	var prev []models.PullRequest
+	fetched := false
+	for {
+		start := time.Now()
+		prs, fetchErr := a.fetcher.Fetch(ctx)
+		if ctx.Err() != nil {
+			return shutdownServer(server)
+		}
+		collector.Update(prs, time.Now(), time.Since(start), fetchErr)
+		if fetchErr != nil {
+			fmt.Printf("%s Failed to fetch pull requests: %v\n", time.Now().Format("15:04:05"), fetchErr)
+		} else {
+			a.saveWatchSnapshot(state, prs, start)
+			if fetched {
+				if err := a.notifyChanges(ctx, diff.Compare(prev, prs)); err != nil {
+					fmt.Printf("%s Failed to send notifications: %v\n", time.Now().Format("15:04:05"), err)
+				}
+			}
+			prev = prs
+			fetched = true
+		}
+
+		delay, rateStatus := a.watchDelay(ctx, state)
+		collector.UpdateRateLimit(state.rateLimit)
+		if a.config.Verbose {
+			fmt.Printf("%s Fetched %d PRs, next update in %s (%s)\n", time.Now().Format("15:04:05"), len(prs), delay, rateStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return shutdownServer(server)
+		case err := <-serveErr:
+			return fmt.Errorf("metrics server failed: %w", err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// shutdownServer stops the metrics server, waiting briefly for in-flight scrapes
+func shutdownServer(server *http.Server) error {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down metrics server: %w", err)
+	}
+	return nil
+}
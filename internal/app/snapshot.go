@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/filter"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/snapshot"
+)
+
+// snapshotScope describes the PR selection so that only snapshots of the same
+// selection are compared (e.g., "org:my-org user:me repo:svc-* filter:ci:failure limit:50")
+func (a *App) snapshotScope() string {
+	var parts []string
+	for _, target := range a.config.Targets {
+		parts = append(parts, target.String())
+	}
+
+	add := func(name string, values ...string) {
+		var nonEmpty []string
+		for _, v := range values {
+			if v != "" {
+				nonEmpty = append(nonEmpty, v)
+			}
+		}
+		if len(nonEmpty) > 0 {
+			parts = append(parts, name+":"+strings.Join(nonEmpty, ","))
+		}
+	}
+
+	rf := a.config.RepositoryFilter
+	add("repo", append(append([]string{}, a.config.Repositories...), a.config.RepositoryPatterns...)...)
+	add("exclude", a.config.ExcludeRepositories...)
+	add("topic", rf.Topics...)
+	add("language", rf.Language)
+	add("visibility", rf.Visibility)
+	if rf.Name != nil {
+		add("name", rf.Name.String())
+	}
+	for _, bot := range a.config.Bots {
+		add("bot", string(bot))
+	}
+	add("filter", a.config.Filter.String())
+	if a.config.SkipChecks {
+		parts = append(parts, "skip-checks")
+	}
+	// Fetches truncated at different limits list different PRs
+	if a.config.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit:%d", a.config.Limit))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// snapshotStore opens the snapshot store for the current selection
+func (a *App) snapshotStore() (*snapshot.Store, error) {
+	dir, err := snapshot.StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Open(dir, a.snapshotScope()), nil
+}
+
+// saveSnapshot records the fetched PRs for later diffs.
+// Failing to save only prints a warning because listing PRs still succeeded.
+func (a *App) saveSnapshot(prs []models.PullRequest, fetchedAt time.Time) {
+	if a.config.NoSnapshot {
+		return
+	}
+
+	store, err := a.snapshotStore()
+	if err == nil {
+		err = store.Save(prs, fetchedAt)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save snapshot: %v\n", err)
+		return
+	}
+
+	if a.config.Verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Saved snapshot of %d PRs to %s\n", len(prs), store.Dir())
+	}
+}
+
+// parseSince parses a --since value relative to now: an age (e.g., 24h, 1d, 2w),
+// a date (YYYY-MM-DD, local time) or an RFC 3339 timestamp
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if age, err := filter.ParseAge(value); err == nil {
+		return now.Add(-age), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since: %s (expected an age like 24h or 1d, a date like 2006-01-02, or an RFC 3339 time)", value)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/stats"
+)
+
+const (
+	// defaultStatsPeriod is the history period of the stats command when --since is omitted
+	defaultStatsPeriod = "90d"
+
+	// statsMaxRepositories is the number of repositories listed in the text report
+	statsMaxRepositories = 20
+)
+
+// runStats reports open PR ages and merge throughput over the history period
+func (a *App) runStats(ctx context.Context, prs []models.PullRequest, fetchedAt time.Time) error {
+	sinceValue := a.config.Since
+	if sinceValue == "" {
+		sinceValue = defaultStatsPeriod
+	}
+	since, err := parseSince(sinceValue, fetchedAt)
+	if err != nil {
+		return err
+	}
+
+	closed, err := a.fetchClosedPullRequests(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR history: %w", err)
+	}
+
+	report := stats.Compute(prs, closed, since, fetchedAt)
+	if a.config.Format == FormatJSON {
+		return formatter.RenderStatsJSON(os.Stdout, report)
+	}
+	formatter.RenderStats(os.Stdout, report, statsMaxRepositories)
+	return nil
+}
+
+// fetchClosedPullRequests fetches the merged and closed PR history with the
+// same repository selection as the PR fetcher
+func (a *App) fetchClosedPullRequests(ctx context.Context, since time.Time) ([]models.ClosedPullRequest, error) {
+	if len(a.config.Repositories) == 0 && len(a.config.RepositoryPatterns) == 0 {
+		return a.client.FetchClosedPullRequests(ctx, a.config.Targets, since)
+	}
+
+	var allPRs []models.ClosedPullRequest
+	for _, repo := range a.config.Repositories {
+		owner, name, err := api.ParseRepository(repo)
+		if err != nil {
+			// Short format (reponame): use target as owner
+			owner = a.config.Targets[0].Name
+			name = repo
+		}
+		if a.client.IsExcluded(owner + "/" + name) {
+			continue
+		}
+
+		prs, err := a.client.FetchClosedRepositoryPullRequests(ctx, owner, name, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch PR history for %s/%s: %w", owner, name, err)
+		}
+		allPRs = append(allPRs, prs...)
+	}
+
+	if len(a.config.RepositoryPatterns) > 0 {
+		prs, err := a.client.FetchClosedPullRequests(ctx, a.config.Targets, since)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, pr := range allPRs {
+			seen[fmt.Sprintf("%s#%d", pr.Repository, pr.Number)] = true
+		}
+		for _, pr := range prs {
+			if !seen[fmt.Sprintf("%s#%d", pr.Repository, pr.Number)] {
+				allPRs = append(allPRs, pr)
+			}
+		}
+	}
+
+	return allPRs, nil
+}
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/diff"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/notify"
+)
+
+const (
+	// minWatchInterval is the shortest allowed --interval for the watch command
+	minWatchInterval = 10 * time.Second
+
+	// lowRateLimitRatio is the fraction of the rate limit below which watch waits for the reset
+	lowRateLimitRatio = 0.1
+
+	// watchSnapshotInterval is the shortest time between snapshots saved by watch and serve,
+	// so that short intervals don't fill the snapshot store used by diff
+	watchSnapshotInterval = time.Hour
+
+	// clearScreen moves the cursor home and clears the terminal
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// watchState tracks rate limit usage between watch cycles
+type watchState struct {
+	remaining int                // Remaining points after the previous cycle (-1 = unknown)
+	resetAt   time.Time          // Reset time of the previous rate limit window
+	cost      int                // Points used by the previous cycle
+	rateLimit *api.RateLimitInfo // Latest rate limit status (nil = unknown)
+	savedAt   time.Time          // When the last snapshot was saved (zero = not yet)
+}
+
+// runWatch fetches PRs every interval and redraws the table in place,
+// marking PRs that appeared, changed or disappeared since the previous cycle
+func (a *App) runWatch(ctx context.Context) error {
+	t := term.FromEnv()
+	isTTY := t.IsTerminalOutput()
+	color := t.IsColorEnabled()
+
+	state := &watchState{remaining: -1}
+	var prev []models.PullRequest
+	fetched := false
+
+	for {
+		prs, fetchErr := a.fetcher.Fetch(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var changes []diff.Change
+		if fetchErr == nil {
+			a.saveWatchSnapshot(state, prs, time.Now())
+			if fetched {
+				changes = diff.Compare(prev, prs)
+				a.resolveRemovals(ctx, changes)
+			}
+			prev = prs
+			fetched = true
+		}
+		notifyErr := a.notifyChanges(ctx, changes)
+
+		now := time.Now()
+		delay, rateStatus := a.watchDelay(ctx, state)
+
+		if isTTY {
+			fmt.Print(clearScreen)
+		}
+		fmt.Printf("gh-deps watch: updated %s, next update at %s (%s)\n\n",
+			now.Format("15:04:05"), now.Add(delay).Format("15:04:05"), rateStatus)
+
+		if fetchErr != nil {
+			fmt.Printf("Failed to fetch pull requests: %v\n\n", fetchErr)
+		}
+		if fetched {
+			a.renderWatchTable(prev, changes, color)
+		}
+		if notifyErr != nil {
+			fmt.Printf("\nFailed to send notifications: %v\n", notifyErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// saveWatchSnapshot saves a snapshot unless one was saved within watchSnapshotInterval
+func (a *App) saveWatchSnapshot(state *watchState, prs []models.PullRequest, now time.Time) {
+	if !state.savedAt.IsZero() && now.Sub(state.savedAt) < watchSnapshotInterval {
+		return
+	}
+	a.saveSnapshot(prs, now)
+	state.savedAt = now
+}
+
+// renderWatchTable renders the PRs and the PRs removed since the previous cycle with change markers
+func (a *App) renderWatchTable(prs []models.PullRequest, changes []diff.Change, color bool) {
+	rows := append([]models.PullRequest{}, prs...)
+	added, changed, merged, closed, removed := 0, 0, 0, 0, 0
+	for _, change := range changes {
+		switch change.Kind {
+		case diff.KindAdded:
+			added++
+		case diff.KindChanged:
+			changed++
+		case diff.KindRemoved:
+			switch change.Removal {
+			case diff.RemovalMerged:
+				merged++
+			case diff.RemovalClosed:
+				closed++
+			default:
+				removed++
+			}
+			rows = append(rows, change.PR)
+		}
+	}
+
+	index := diff.ByKey(changes)
+	formatter.RenderTable(os.Stdout, rows, formatter.TableOptions{
+		SortKeys: a.config.SortKeys,
+		Columns:  a.config.Columns,
+		Width:    terminalWidth(),
+		Marker: func(pr *models.PullRequest) string {
+			change, ok := index[diff.Key(pr)]
+			if !ok {
+				return ""
+			}
+			return formatChangeMarker(change, color)
+		},
+	})
+
+	fmt.Printf("\nTotal: %d dependency update PRs", len(prs))
+	if len(changes) > 0 {
+		fmt.Printf("  |  %d new, %d changed, %d merged, %d closed, %d gone", added, changed, merged, closed, removed)
+	}
+	fmt.Println()
+}
+
+// resolveRemovals looks up whether each removed PR was merged or closed.
+// PRs whose lookup fails stay marked as gone.
+func (a *App) resolveRemovals(ctx context.Context, changes []diff.Change) {
+	for i := range changes {
+		change := &changes[i]
+		if change.Kind != diff.KindRemoved {
+			continue
+		}
+		owner, repo, err := api.ParseRepository(change.PR.Repository)
+		if err != nil {
+			continue
+		}
+		state, err := a.client.FetchPullRequestState(ctx, owner, repo, change.PR.Number)
+		if err != nil {
+			if a.config.Verbose {
+				fmt.Fprintf(os.Stderr, "[DEBUG] Failed to fetch state of %s: %v\n", diff.Key(&change.PR), err)
+			}
+			continue
+		}
+		switch {
+		case state.Merged:
+			change.Removal = diff.RemovalMerged
+		case state.State == "closed":
+			change.Removal = diff.RemovalClosed
+		default:
+			change.Removal = diff.RemovalOpen
+		}
+	}
+}
+
+// notifyChanges sends notifications for the changes between two cycles
+func (a *App) notifyChanges(ctx context.Context, changes []diff.Change) error {
+	if len(a.config.Notifiers) == 0 {
+		return nil
+	}
+	events := notify.EventsFromChanges(changes, a.config.NotifyEvents)
+	if len(events) == 0 {
+		return nil
+	}
+	if a.config.Verbose {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Sending %d notification(s)\n", len(events))
+	}
+	return a.config.Notifiers.Notify(ctx, events)
+}
+
+// watchDelay returns how long to wait before the next cycle and a rate limit status.
+// When the remaining rate limit would not cover a few more cycles, it waits for
+// the rate limit to reset instead of the interval.
+func (a *App) watchDelay(ctx context.Context, state *watchState) (time.Duration, string) {
+	delay := a.config.Interval
+
+	info, err := a.client.CheckRateLimit(ctx)
+	if err != nil {
+		if a.config.Verbose {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Failed to check rate limit: %v\n", err)
+		}
+		return delay, "rate limit unknown"
+	}
+
+	// Estimate the cost of a cycle within the same rate limit window
+	if state.remaining >= 0 && info.ResetAt.Equal(state.resetAt) && state.remaining > info.Remaining {
+		state.cost = state.remaining - info.Remaining
+	}
+	state.remaining = info.Remaining
+	state.resetAt = info.ResetAt
+	state.rateLimit = info
+
+	threshold := int(float64(info.Limit) * lowRateLimitRatio)
+	if minimum := state.cost * 2; minimum > threshold {
+		threshold = minimum
+	}
+
+	status := fmt.Sprintf("rate limit %d/%d", info.Remaining, info.Limit)
+	if info.Remaining < threshold {
+		if untilReset := time.Until(info.ResetAt); untilReset > delay {
+			delay = untilReset
+		}
+		status += fmt.Sprintf(", low: waiting for reset at %s", info.ResetAt.Local().Format("15:04:05"))
+	}
+
+	return delay, status
+}
+
+// formatChangeMarker returns the marker shown in the CHANGE column
+func formatChangeMarker(change diff.Change, color bool) string {
+	var marker, ansi string
+	switch change.Kind {
+	case diff.KindAdded:
+		marker, ansi = "+ new", "32" // Green
+	case diff.KindRemoved:
+		switch change.Removal {
+		case diff.RemovalMerged:
+			marker, ansi = "- merged", "35" // Magenta
+		case diff.RemovalClosed:
+			marker, ansi = "- closed", "31" // Red
+		case diff.RemovalOpen:
+			marker, ansi = "- unlisted", "90" // Gray
+		default:
+			marker, ansi = "- gone", "31" // Red
+		}
+	default:
+		marker, ansi = "~ "+strings.Join(change.Fields, ","), "33" // Yellow
+	}
+
+	if !color {
+		return marker
+	}
+	return "\x1b[" + ansi + "m" + marker + "\x1b[0m"
+}
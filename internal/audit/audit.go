@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/snapshot"
+)
+
+// fileName is the name of the audit log in the state directory
+const fileName = "audit.jsonl"
+
+// Entry is an action taken on a PR, written as one JSON line
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title,omitempty"`
+	Action     string    `json:"action"`            // merge, rebase, approve, comment, rerun, or "undo <action>"
+	Success    bool      `json:"success"`           // Whether the action succeeded
+	Message    string    `json:"message"`           // Result, or the error text of a failed action
+	Comment    string    `json:"comment,omitempty"` // Posted comment body
+	CommentID  int       `json:"comment_id,omitempty"`
+	ReviewID   int64     `json:"review_id,omitempty"`
+}
+
+// Log appends entries to a JSONL file
+type Log struct {
+	path string
+}
+
+// DefaultPath returns the audit log in the gh-deps state directory
+func DefaultPath() (string, error) {
+	dir, err := snapshot.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// New returns the audit log written to path
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the file the entries are written to
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes an entry at the end of the file, creating it if needed
+func (l *Log) Append(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Kind describes how a PR changed between two fetches
+type Kind string
+
+const (
+	KindAdded   Kind = "added"   // PR appeared
+	KindRemoved Kind = "removed" // PR disappeared (merged or closed)
+	KindChanged Kind = "changed" // PR state changed
+)
+
+// Removal describes what happened to a removed PR
+type Removal string
+
+const (
+	RemovalUnknown Removal = ""       // Not looked up (or the lookup failed)
+	RemovalMerged  Removal = "merged" // PR was merged
+	RemovalClosed  Removal = "closed" // PR was closed without merging
+	RemovalOpen    Removal = "open"   // PR is still open but no longer selected (e.g., by --filter)
+)
+
+// Fields compared between fetches
+const (
+	FieldCI        = "ci"
+	FieldMergeable = "mergeable"
+	FieldReview    = "review"
+	FieldVersion   = "version"
+)
+
+// Change is a difference for a single PR between two fetches
+type Change struct {
+	Kind     Kind
+	PR       models.PullRequest  // Current state (last known state for removed PRs)
+	Previous *models.PullRequest // Previous state (nil for added PRs)
+	Fields   []string            // Changed fields (only for changed PRs)
+	Removal  Removal             // What happened to the PR (only for removed PRs, set by the caller)
+}
+
+// Has reports whether the field changed
+func (c Change) Has(field string) bool {
+	return slices.Contains(c.Fields, field)
+}
+
+// Key returns the identity of a PR across fetches (owner/repo#number)
+func Key(pr *models.PullRequest) string {
+	return fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+}
+
+// Compare returns the changes from prev to curr.
+// Added and changed PRs are returned in the order of curr, followed by
+// removed PRs in the order of prev.
+func Compare(prev, curr []models.PullRequest) []Change {
+	previous := make(map[string]*models.PullRequest, len(prev))
+	for i := range prev {
+		previous[Key(&prev[i])] = &prev[i]
+	}
+
+	var changes []Change
+	current := make(map[string]bool, len(curr))
+	for _, pr := range curr {
+		key := Key(&pr)
+		current[key] = true
+
+		old, ok := previous[key]
+		if !ok {
+			changes = append(changes, Change{Kind: KindAdded, PR: pr})
+			continue
+		}
+		if fields := changedFields(old, &pr); len(fields) > 0 {
+			changes = append(changes, Change{Kind: KindChanged, PR: pr, Previous: old, Fields: fields})
+		}
+	}
+
+	for _, pr := range prev {
+		if !current[Key(&pr)] {
+			changes = append(changes, Change{Kind: KindRemoved, PR: pr, Previous: &pr})
+		}
+	}
+
+	return changes
+}
+
+// ByKey indexes changes by PR key
+func ByKey(changes []Change) map[string]Change {
+	index := make(map[string]Change, len(changes))
+	for _, change := range changes {
+		index[Key(&change.PR)] = change
+	}
+	return index
+}
+
+// changedFields returns the compared fields that differ between two states of a PR
+func changedFields(old, pr *models.PullRequest) []string {
+	var fields []string
+	if old.CheckSummary.Status != pr.CheckSummary.Status {
+		fields = append(fields, FieldCI)
+	}
+	if old.MergeableState != pr.MergeableState {
+		fields = append(fields, FieldMergeable)
+	}
+	if old.ReviewDecision != pr.ReviewDecision {
+		fields = append(fields, FieldReview)
+	}
+	if old.Version != pr.Version {
+		fields = append(fields, FieldVersion)
+	}
+	return fields
+}
@@ -0,0 +1,443 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/pattern"
+)
+
+// Comparison operators
+const (
+	opEqual        = ":"
+	opGreater      = ">"
+	opLess         = "<"
+	opGreaterEqual = ">="
+	opLessEqual    = "<="
+)
+
+// fieldBuilder builds a predicate from an operator and a value
+type fieldBuilder func(op, value string) (predicateNode, error)
+
+// fields maps field names (and aliases) to predicate builders
+var fields = map[string]fieldBuilder{
+	"bot":        buildBot,
+	"ci":         buildCI,
+	"merge":      buildMerge,
+	"mergeable":  buildMerge,
+	"update":     buildUpdate,
+	"age":        buildAge,
+	"created":    buildCreated,
+	"label":      buildLabel,
+	"repo":       buildRepo,
+	"dep":        buildDependency,
+	"dependency": buildDependency,
+	"title":      buildText(func(pr *models.PullRequest) string { return pr.Title }),
+	"author":     buildText(func(pr *models.PullRequest) string { return pr.Author }),
+	"version":    buildText(func(pr *models.PullRequest) string { return pr.Version }),
+	"number":     buildNumber,
+}
+
+// Fields returns the supported field names in alphabetical order
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseTerm parses a field predicate (e.g., "ci:success", "age>7d") or a bare word
+func parseTerm(text string) (node, error) {
+	i := strings.IndexAny(text, ":<>=")
+	if i <= 0 || !isFieldName(text[:i]) {
+		return buildWord(text), nil
+	}
+
+	name := strings.ToLower(text[:i])
+	rest := text[i:]
+
+	op := opEqual
+	for _, candidate := range []string{opGreaterEqual, opLessEqual, opGreater, opLess, opEqual, "="} {
+		if strings.HasPrefix(rest, candidate) {
+			op = candidate
+			break
+		}
+	}
+	value := rest[len(op):]
+	if op == "=" {
+		op = opEqual
+	}
+
+	build, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q (expected one of %s)", name, strings.Join(Fields(), ", "))
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s%s", name, op)
+	}
+
+	predicate, err := build(op, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s%s%s: %w", name, op, value, err)
+	}
+	return predicate, nil
+}
+
+// isFieldName reports whether s looks like a field name (letters only)
+func isFieldName(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildWord matches a bare word against the PR's searchable text
+func buildWord(word string) predicateNode {
+	word = strings.ToLower(word)
+	return func(pr *models.PullRequest, _ time.Time) bool {
+		return strings.Contains(SearchText(pr), word)
+	}
+}
+
+// SearchText returns the lowercase text that bare words are matched against
+func SearchText(pr *models.PullRequest) string {
+	return strings.ToLower(strings.Join([]string{
+		pr.RepoName(), pr.Title, pr.BotType.DisplayName(),
+		strings.Join(pr.Labels, " "), pr.Version, pr.Dependency,
+	}, " "))
+}
+
+// splitValues splits a comma-separated value list ("minor,patch")
+func splitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// requireEqual returns an error for comparison operators on non-ordered fields
+func requireEqual(op string) error {
+	if op != opEqual {
+		return fmt.Errorf("operator %s is not supported for this field", op)
+	}
+	return nil
+}
+
+// anyOf builds a predicate matching if any value predicate matches
+func anyOf(predicates []func(pr *models.PullRequest) bool) predicateNode {
+	return func(pr *models.PullRequest, _ time.Time) bool {
+		for _, predicate := range predicates {
+			if predicate(pr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func buildBot(op, value string) (predicateNode, error) {
+	if err := requireEqual(op); err != nil {
+		return nil, err
+	}
+	var predicates []func(pr *models.PullRequest) bool
+	for _, v := range splitValues(value) {
+		botType, err := models.ParseBotType(v)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, func(pr *models.PullRequest) bool { return pr.BotType == botType })
+	}
+	return anyOf(predicates), nil
+}
+
+// ciStatuses maps accepted ci: values to check statuses
+var ciStatuses = map[string]models.CheckStatus{
+	"success": models.StatusSuccess,
+	"pass":    models.StatusSuccess,
+	"passing": models.StatusSuccess,
+	"green":   models.StatusSuccess,
+	"failure": models.StatusFailure,
+	"fail":    models.StatusFailure,
+	"failing": models.StatusFailure,
+	"red":     models.StatusFailure,
+	"pending": models.StatusPending,
+	"running": models.StatusPending,
+	"none":    models.StatusNone,
+}
+
+func buildCI(op, value string) (predicateNode, error) {
+	if err := requireEqual(op); err != nil {
+		return nil, err
+	}
+	var predicates []func(pr *models.PullRequest) bool
+	for _, v := range splitValues(value) {
+		status, ok := ciStatuses[strings.ToLower(v)]
+		if !ok {
+			return nil, fmt.Errorf("expected success, failure, pending, or none")
+		}
+		predicates = append(predicates, func(pr *models.PullRequest) bool { return pr.CheckSummary.Status == status })
+	}
+	return anyOf(predicates), nil
+}
+
+// mergeStates maps accepted merge: values to mergeable states
+var mergeStates = map[string]models.MergeableState{
+	"mergeable":   models.MergeableStateMergeable,
+	"clean":       models.MergeableStateMergeable,
+	"conflicting": models.MergeableStateConflicting,
+	"conflict":    models.MergeableStateConflicting,
+	"conflicts":   models.MergeableStateConflicting,
+	"unknown":     models.MergeableStateUnknown,
+}
+
+func buildMerge(op, value string) (predicateNode, error) {
+	if err := requireEqual(op); err != nil {
+		return nil, err
+	}
+	var predicates []func(pr *models.PullRequest) bool
+	for _, v := range splitValues(value) {
+		state, ok := mergeStates[strings.ToLower(v)]
+		if !ok {
+			return nil, fmt.Errorf("expected mergeable, conflicting, or unknown")
+		}
+		predicates = append(predicates, func(pr *models.PullRequest) bool { return pr.MergeableState == state })
+	}
+	return anyOf(predicates), nil
+}
+
+// parseUpdateType parses an update type name
+func parseUpdateType(value string) (models.UpdateType, error) {
+	updateType := models.UpdateType(strings.ToLower(value))
+	switch updateType {
+	case models.UpdateMajor, models.UpdateMinor, models.UpdatePatch, models.UpdateDigest, models.UpdateUnknown:
+		return updateType, nil
+	default:
+		return "", fmt.Errorf("expected major, minor, patch, digest, or unknown")
+	}
+}
+
+func buildUpdate(op, value string) (predicateNode, error) {
+	if op != opEqual {
+		// Ordered comparison by severity (e.g., update>=minor)
+		updateType, err := parseUpdateType(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(pr *models.PullRequest, _ time.Time) bool {
+			return compareInts(op, pr.UpdateType.Rank(), updateType.Rank())
+		}, nil
+	}
+
+	var predicates []func(pr *models.PullRequest) bool
+	for _, v := range splitValues(value) {
+		updateType, err := parseUpdateType(v)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, func(pr *models.PullRequest) bool { return pr.UpdateType == updateType })
+	}
+	return anyOf(predicates), nil
+}
+
+// ParseAge parses an age such as "30m", "12h", "7d" or "2w"
+func ParseAge(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid age %q (expected e.g. 12h, 7d, 2w)", value)
+	}
+
+	number, err := strconv.ParseFloat(value[:len(value)-1], 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid age %q (expected e.g. 12h, 7d, 2w)", value)
+	}
+
+	var unit time.Duration
+	switch value[len(value)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid age unit in %q (expected m, h, d, or w)", value)
+	}
+
+	return time.Duration(number * float64(unit)), nil
+}
+
+// buildAge compares the PR age; "age:7d" is the same as "age>=7d"
+func buildAge(op, value string) (predicateNode, error) {
+	age, err := ParseAge(value)
+	if err != nil {
+		return nil, err
+	}
+	if op == opEqual {
+		op = opGreaterEqual
+	}
+	return func(pr *models.PullRequest, now time.Time) bool {
+		return compareDurations(op, pr.Age(now), age)
+	}, nil
+}
+
+// buildCreated compares the PR creation date (YYYY-MM-DD, local time);
+// "created:2026-01-02" matches PRs created on that day
+func buildCreated(op, value string) (predicateNode, error) {
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("expected a date in YYYY-MM-DD format")
+	}
+	next := day.AddDate(0, 0, 1)
+
+	return func(pr *models.PullRequest, _ time.Time) bool {
+		created := pr.CreatedAt
+		switch op {
+		case opGreater:
+			return !created.Before(next)
+		case opGreaterEqual:
+			return !created.Before(day)
+		case opLess:
+			return created.Before(day)
+		case opLessEqual:
+			return created.Before(next)
+		default:
+			return !created.Before(day) && created.Before(next)
+		}
+	}, nil
+}
+
+func buildLabel(op, value string) (predicateNode, error) {
+	patterns, err := compileValues(op, value)
+	if err != nil {
+		return nil, err
+	}
+	return func(pr *models.PullRequest, _ time.Time) bool {
+		for _, label := range pr.Labels {
+			for _, p := range patterns {
+				if p.Match(label) {
+					return true
+				}
+			}
+		}
+		return false
+	}, nil
+}
+
+// buildRepo matches repositories by glob or "re:" pattern (see pattern.MatchRepository);
+// plain values match any repository whose owner/name contains them
+func buildRepo(op, value string) (predicateNode, error) {
+	if err := requireEqual(op); err != nil {
+		return nil, err
+	}
+	var predicates []func(pr *models.PullRequest) bool
+	for _, v := range splitValues(value) {
+		if !pattern.IsPattern(v) {
+			v = strings.ToLower(v)
+			predicates = append(predicates, func(pr *models.PullRequest) bool {
+				return strings.Contains(strings.ToLower(pr.Repository), v)
+			})
+			continue
+		}
+		p, err := pattern.Compile(v)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, func(pr *models.PullRequest) bool { return p.MatchRepository(pr.Repository) })
+	}
+	return anyOf(predicates), nil
+}
+
+func buildDependency(op, value string) (predicateNode, error) {
+	patterns, err := compileValues(op, value)
+	if err != nil {
+		return nil, err
+	}
+	return func(pr *models.PullRequest, _ time.Time) bool {
+		for _, p := range patterns {
+			if p.Match(pr.Dependency) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// compileValues compiles comma-separated glob or "re:" patterns
+func compileValues(op, value string) ([]*pattern.Pattern, error) {
+	if err := requireEqual(op); err != nil {
+		return nil, err
+	}
+	return pattern.CompileAll(splitValues(value))
+}
+
+// buildText builds a case-insensitive substring predicate for a text field
+func buildText(get func(pr *models.PullRequest) string) fieldBuilder {
+	return func(op, value string) (predicateNode, error) {
+		if err := requireEqual(op); err != nil {
+			return nil, err
+		}
+		var predicates []func(pr *models.PullRequest) bool
+		for _, v := range splitValues(value) {
+			v = strings.ToLower(v)
+			predicates = append(predicates, func(pr *models.PullRequest) bool {
+				return strings.Contains(strings.ToLower(get(pr)), v)
+			})
+		}
+		return anyOf(predicates), nil
+	}
+}
+
+func buildNumber(op, value string) (predicateNode, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("expected a PR number")
+	}
+	return func(pr *models.PullRequest, _ time.Time) bool {
+		return compareInts(op, pr.Number, number)
+	}, nil
+}
+
+// compareInts applies a comparison operator (":" means equal)
+func compareInts(op string, a, b int) bool {
+	switch op {
+	case opGreater:
+		return a > b
+	case opGreaterEqual:
+		return a >= b
+	case opLess:
+		return a < b
+	case opLessEqual:
+		return a <= b
+	default:
+		return a == b
+	}
+}
+
+// compareDurations applies a comparison operator (":" means equal)
+func compareDurations(op string, a, b time.Duration) bool {
+	switch op {
+	case opGreater:
+		return a > b
+	case opGreaterEqual:
+		return a >= b
+	case opLess:
+		return a < b
+	case opLessEqual:
+		return a <= b
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,256 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Filter is a parsed filter expression that can be evaluated against PRs.
+//
+// Syntax:
+//   - Terms separated by spaces must all match (AND)
+//   - "OR" (or "|") between terms matches either side; AND binds tighter
+//   - A leading "-" negates a term or a parenthesized group
+//   - Parentheses group sub-expressions
+//   - field:value, field>value, field<value, field>=value, field<=value
+//     compare a PR field (see Fields); "a,b" values match any of them
+//   - Bare words match the repository, title, bot, labels, version and dependency
+//
+// Example: bot:renovate ci:success merge:mergeable age>7d label:automerge -repo:legacy update:minor
+type Filter struct {
+	raw  string
+	root node
+}
+
+// Parse parses a filter expression. An empty expression matches every PR.
+func Parse(expr string) (*Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Filter{raw: strings.TrimSpace(expr)}
+	if len(tokens) == 0 {
+		return f, nil
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.tokens[p.pos].describe())
+	}
+
+	f.root = root
+	return f, nil
+}
+
+// Match reports whether the PR matches the filter, evaluating ages against the current time
+func (f *Filter) Match(pr models.PullRequest) bool {
+	return f.MatchAt(pr, time.Now())
+}
+
+// MatchAt reports whether the PR matches the filter, evaluating ages against now
+func (f *Filter) MatchAt(pr models.PullRequest, now time.Time) bool {
+	if f == nil || f.root == nil {
+		return true
+	}
+	return f.root.match(&pr, now)
+}
+
+// Apply returns the PRs that match the filter
+func (f *Filter) Apply(prs []models.PullRequest) []models.PullRequest {
+	now := time.Now()
+	matched := []models.PullRequest{}
+	for _, pr := range prs {
+		if f.MatchAt(pr, now) {
+			matched = append(matched, pr)
+		}
+	}
+	return matched
+}
+
+// IsEmpty reports whether the filter matches everything
+func (f *Filter) IsEmpty() bool {
+	return f == nil || f.root == nil
+}
+
+// String returns the expression as written
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.raw
+}
+
+// node is an element of the parsed expression tree
+type node interface {
+	match(pr *models.PullRequest, now time.Time) bool
+}
+
+// andNode matches if all children match
+type andNode []node
+
+func (n andNode) match(pr *models.PullRequest, now time.Time) bool {
+	for _, child := range n {
+		if !child.match(pr, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// orNode matches if any child matches
+type orNode []node
+
+func (n orNode) match(pr *models.PullRequest, now time.Time) bool {
+	for _, child := range n {
+		if child.match(pr, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// notNode negates its child
+type notNode struct {
+	child node
+}
+
+func (n notNode) match(pr *models.PullRequest, now time.Time) bool {
+	return !n.child.match(pr, now)
+}
+
+// predicateNode evaluates a single field predicate or bare word
+type predicateNode func(pr *models.PullRequest, now time.Time) bool
+
+func (n predicateNode) match(pr *models.PullRequest, now time.Time) bool {
+	return n(pr, now)
+}
+
+// parser is a recursive descent parser over tokens
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *parser) parseOr() (node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := orNode{first}
+	for p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOr {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, next)
+	}
+
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return nodes, nil
+}
+
+// parseAnd parses: unary+
+func (p *parser) parseAnd() (node, error) {
+	var nodes andNode
+	for p.pos < len(p.tokens) {
+		kind := p.tokens[p.pos].kind
+		if kind == tokenOr || kind == tokenRParen {
+			break
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+
+	switch len(nodes) {
+	case 0:
+		if p.pos < len(p.tokens) {
+			return nil, fmt.Errorf("expected a term before %s", p.tokens[p.pos].describe())
+		}
+		return nil, fmt.Errorf("expected a term at end of expression")
+	case 1:
+		return nodes[0], nil
+	default:
+		return nodes, nil
+	}
+}
+
+// parseUnary parses a parenthesized group, a negated group "-(...)", or an optionally negated term
+func (p *parser) parseUnary() (node, error) {
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	switch tok.kind {
+	case tokenLParen:
+		return p.parseGroup()
+	case tokenTerm:
+		// "-(" negates a group
+		if tok.text == "-" && p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenLParen {
+			p.pos++
+			group, err := p.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			return notNode{child: group}, nil
+		}
+
+		text := tok.text
+		negate := len(text) > 1 && strings.HasPrefix(text, "-")
+		if negate {
+			text = text[1:]
+		}
+
+		n, err := parseTerm(text)
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			return notNode{child: n}, nil
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s", tok.describe())
+	}
+}
+
+// parseGroup parses the rest of a parenthesized group after "("
+func (p *parser) parseGroup() (node, error) {
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenRParen {
+		return nil, fmt.Errorf("missing closing parenthesis")
+	}
+	p.pos++
+	return n, nil
+}
+
+// describe returns a human-readable description of a token for error messages
+func (t token) describe() string {
+	switch t.kind {
+	case tokenOr:
+		return "OR"
+	case tokenLParen:
+		return `"("`
+	case tokenRParen:
+		return `")"`
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
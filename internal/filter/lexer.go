@@ -0,0 +1,74 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind identifies the type of a lexical token
+type tokenKind int
+
+const (
+	tokenTerm   tokenKind = iota // Predicate or bare word (e.g., "ci:success", "-repo:legacy", "react")
+	tokenOr                      // OR keyword
+	tokenLParen                  // (
+	tokenRParen                  // )
+)
+
+// token is a single lexical token of a filter expression
+type token struct {
+	kind tokenKind
+	text string // Unquoted text for tokenTerm
+}
+
+// tokenize splits a filter expression into tokens.
+// Whitespace separates terms, double quotes group text containing spaces
+// (e.g., title:"update react"), and parentheses group sub-expressions.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	var current strings.Builder
+	inQuotes := false
+	hasTerm := false
+
+	flush := func() {
+		if !hasTerm {
+			return
+		}
+		text := current.String()
+		if text == "OR" || text == "|" {
+			tokens = append(tokens, token{kind: tokenOr})
+		} else {
+			tokens = append(tokens, token{kind: tokenTerm, text: text})
+		}
+		current.Reset()
+		hasTerm = false
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasTerm = true
+		case inQuotes:
+			current.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		case r == '(':
+			flush()
+			tokens = append(tokens, token{kind: tokenLParen})
+		case r == ')':
+			flush()
+			tokens = append(tokens, token{kind: tokenRParen})
+		default:
+			current.WriteRune(r)
+			hasTerm = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+
+	return tokens, nil
+}
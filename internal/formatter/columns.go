@@ -0,0 +1,168 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Column is a table column that can be selected with --columns
+type Column struct {
+	Name     string // Name used in --columns
+	Header   string // Table header
+	MaxWidth int    // Width limit when the terminal width is unknown (0 = unlimited)
+	MinWidth int    // Narrowest width when shrinking to fit the terminal (0 = never shrunk)
+	value    func(pr *models.PullRequest, now time.Time) string
+}
+
+// Columns lists all available table columns
+var Columns = []Column{
+	{Name: "number", Header: "NUMBER", value: func(pr *models.PullRequest, _ time.Time) string {
+		return fmt.Sprintf("#%d", pr.Number)
+	}},
+	{Name: "repo", Header: "REPO", MaxWidth: 20, MinWidth: 10, value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.RepoName()
+	}},
+	{Name: "full-repo", Header: "REPOSITORY", MaxWidth: 40, MinWidth: 15, value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.Repository
+	}},
+	{Name: "bot", Header: "BOT", value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.BotType.DisplayName()
+	}},
+	{Name: "author", Header: "AUTHOR", MaxWidth: 20, MinWidth: 10, value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.Author
+	}},
+	{Name: "ci", Header: "CI", value: func(pr *models.PullRequest, _ time.Time) string {
+		return CheckStatus(pr.CheckSummary.Status)
+	}},
+	{Name: "merge", Header: "MERGE", value: func(pr *models.PullRequest, _ time.Time) string {
+		return MergeableState(pr.MergeableState)
+	}},
+	{Name: "review", Header: "REVIEW", value: func(pr *models.PullRequest, _ time.Time) string {
+		return formatReviewDecision(pr.ReviewDecision)
+	}},
+	{Name: "labels", Header: "LABELS", MaxWidth: 30, MinWidth: 10, value: func(pr *models.PullRequest, _ time.Time) string {
+		return formatLabels(pr.Labels)
+	}},
+	{Name: "date", Header: "DATE", value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.FormattedDate()
+	}},
+	{Name: "age", Header: "AGE", value: func(pr *models.PullRequest, now time.Time) string {
+		return fmt.Sprintf("%dd", int(pr.Age(now).Hours()/24))
+	}},
+	{Name: "head", Header: "HEAD", MaxWidth: 40, MinWidth: 12, value: func(pr *models.PullRequest, _ time.Time) string {
+		return orDash(pr.HeadRef)
+	}},
+	{Name: "base", Header: "BASE", MaxWidth: 20, MinWidth: 8, value: func(pr *models.PullRequest, _ time.Time) string {
+		return orDash(pr.BaseRef)
+	}},
+	{Name: "changes", Header: "CHANGES", value: func(pr *models.PullRequest, _ time.Time) string {
+		return fmt.Sprintf("+%d -%d", pr.Additions, pr.Deletions)
+	}},
+	{Name: "dependency", Header: "DEPENDENCY", MaxWidth: 30, MinWidth: 12, value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.Dependency
+	}},
+	{Name: "update-type", Header: "UPDATE", value: func(pr *models.PullRequest, _ time.Time) string {
+		return string(pr.UpdateType)
+	}},
+	{Name: "version", Header: "VERSION", MaxWidth: 30, MinWidth: 12, value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.Version
+	}},
+	{Name: "title", Header: "TITLE", MaxWidth: 60, MinWidth: 20, value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.Title
+	}},
+	{Name: "url", Header: "URL", value: func(pr *models.PullRequest, _ time.Time) string {
+		return pr.URL
+	}},
+}
+
+// DefaultColumns are the columns shown when --columns is not specified
+var DefaultColumns = mustParseColumns("repo,bot,ci,merge,labels,date,version,title,url")
+
+// ParseColumns parses a comma-separated list of column names in display order
+// An empty list selects DefaultColumns
+func ParseColumns(spec string) ([]Column, error) {
+	columns, err := parseColumnNames(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return DefaultColumns, nil
+	}
+	return columns, nil
+}
+
+// parseColumnNames looks up each column of a comma-separated list
+func parseColumnNames(spec string) ([]Column, error) {
+	var columns []Column
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column: %s", name)
+		}
+		seen[name] = true
+
+		column, ok := findColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column: %s (expected one of %s)", name, strings.Join(ColumnNames(), ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// ColumnNames returns the names of all available columns
+func ColumnNames() []string {
+	names := make([]string, 0, len(Columns))
+	for _, column := range Columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// findColumn looks up a column by name
+func findColumn(name string) (Column, bool) {
+	for _, column := range Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// mustParseColumns parses a built-in column list
+func mustParseColumns(spec string) []Column {
+	columns, err := parseColumnNames(spec)
+	if err != nil {
+		panic(err)
+	}
+	return columns
+}
+
+// formatReviewDecision returns a short label for the review decision
+func formatReviewDecision(decision models.ReviewDecision) string {
+	switch decision {
+	case models.ReviewDecisionApproved:
+		return "approved"
+	case models.ReviewDecisionChangesRequested:
+		return "changes"
+	case models.ReviewDecisionReviewRequired:
+		return "required"
+	default:
+		return "-"
+	}
+}
+
+// orDash returns "-" for empty values
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,135 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/diff"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// jsonChange is the JSON representation of a PR change
+type jsonChange struct {
+	Kind              string   `json:"kind"`
+	Fields            []string `json:"fields"`
+	Repository        string   `json:"repository"`
+	Number            int      `json:"number"`
+	Title             string   `json:"title"`
+	Bot               string   `json:"bot"`
+	URL               string   `json:"url"`
+	CI                string   `json:"ci"`
+	PreviousCI        string   `json:"previous_ci,omitempty"`
+	Mergeable         string   `json:"mergeable"`
+	PreviousMergeable string   `json:"previous_mergeable,omitempty"`
+}
+
+// diffSection groups changes for the text report
+type diffSection struct {
+	title string
+	match func(change diff.Change) bool
+}
+
+// diffSections are the sections of the text report in display order
+var diffSections = []diffSection{
+	{"New", func(c diff.Change) bool { return c.Kind == diff.KindAdded }},
+	{"Gone (merged or closed)", func(c diff.Change) bool { return c.Kind == diff.KindRemoved }},
+	{"Became conflicting", func(c diff.Change) bool {
+		return c.Has(diff.FieldMergeable) && c.PR.MergeableState == models.MergeableStateConflicting
+	}},
+	{"CI changed", func(c diff.Change) bool { return c.Has(diff.FieldCI) }},
+	{"Other changes", func(c diff.Change) bool {
+		return c.Kind == diff.KindChanged && !c.Has(diff.FieldCI) &&
+			!(c.Has(diff.FieldMergeable) && c.PR.MergeableState == models.MergeableStateConflicting)
+	}},
+}
+
+// RenderDiff writes a text report of changes grouped by kind.
+// A changed PR may appear in several sections (e.g., CI changed and became conflicting).
+func RenderDiff(w io.Writer, changes []diff.Change) {
+	for _, section := range diffSections {
+		var matched []diff.Change
+		for _, change := range changes {
+			if section.match(change) {
+				matched = append(matched, change)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s (%d)\n", section.title, len(matched))
+		for _, change := range matched {
+			pr := change.PR
+			fmt.Fprintf(w, "  %s#%d  %s  %s\n", pr.Repository, pr.Number, describeChange(change), TruncateWithEllipsis(pr.Title, 60))
+		}
+	}
+}
+
+// describeChange returns a short description of what changed
+func describeChange(change diff.Change) string {
+	if change.Kind != diff.KindChanged || change.Previous == nil {
+		return change.PR.BotType.DisplayName()
+	}
+
+	prev, pr := change.Previous, change.PR
+	description := ""
+	add := func(s string) {
+		if description != "" {
+			description += ", "
+		}
+		description += s
+	}
+	if change.Has(diff.FieldCI) {
+		add(fmt.Sprintf("CI %s %s %s", CheckStatus(prev.CheckSummary.Status), symbols.Arrow, CheckStatus(pr.CheckSummary.Status)))
+	}
+	if change.Has(diff.FieldMergeable) {
+		add(fmt.Sprintf("merge %s %s %s", MergeableState(prev.MergeableState), symbols.Arrow, MergeableState(pr.MergeableState)))
+	}
+	if change.Has(diff.FieldReview) {
+		add(fmt.Sprintf("review %s %s %s", formatReviewDecision(prev.ReviewDecision), symbols.Arrow, formatReviewDecision(pr.ReviewDecision)))
+	}
+	if change.Has(diff.FieldVersion) {
+		add(fmt.Sprintf("version %s %s %s", orDash(prev.Version), symbols.Arrow, orDash(pr.Version)))
+	}
+	return description
+}
+
+// jsonDiff is the JSON output of diff
+type jsonDiff struct {
+	BaselineAt *time.Time   `json:"baseline_at"` // Time of the snapshot compared with (null = no snapshot yet)
+	Changes    []jsonChange `json:"changes"`
+}
+
+// RenderDiffJSON writes the changes since the baseline snapshot taken at baselineAt as JSON
+func RenderDiffJSON(w io.Writer, baselineAt *time.Time, changes []diff.Change) error {
+	out := make([]jsonChange, 0, len(changes))
+	for _, change := range changes {
+		pr := change.PR
+		fields := change.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		c := jsonChange{
+			Kind:       string(change.Kind),
+			Fields:     fields,
+			Repository: pr.Repository,
+			Number:     pr.Number,
+			Title:      pr.Title,
+			Bot:        string(pr.BotType),
+			URL:        pr.URL,
+			CI:         pr.CheckSummary.Status.Name(),
+			Mergeable:  string(pr.MergeableState),
+		}
+		if change.Kind == diff.KindChanged && change.Previous != nil {
+			c.PreviousCI = change.Previous.CheckSummary.Status.Name()
+			c.PreviousMergeable = string(change.Previous.MergeableState)
+		}
+		out = append(out, c)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonDiff{BaselineAt: baselineAt, Changes: out})
+}
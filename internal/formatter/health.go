@@ -0,0 +1,66 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/swfz/gh-deps/internal/health"
+)
+
+// jsonRepositoryHealth is the JSON representation of a repository's health
+type jsonRepositoryHealth struct {
+	Repository       string `json:"repository"`
+	Score            int    `json:"score"`
+	NeedsAttention   bool   `json:"needs_attention"`
+	Open             int    `json:"open"`
+	Failing          int    `json:"failing"`
+	Conflicting      int    `json:"conflicting"`
+	Security         int    `json:"security"`
+	OldestAgeSeconds int64  `json:"oldest_age_seconds"`
+}
+
+// RenderHealth displays repository health scores in a table, worst first
+func RenderHealth(w io.Writer, repos []health.RepositoryHealth) {
+	table := tablewriter.NewWriter(w)
+	table.Header("#", "REPOSITORY", "SCORE", "OPEN", "OLDEST", "FAILING", "CONFLICTING", "SECURITY")
+	for i, h := range repos {
+		score := fmt.Sprintf("%d", h.Score)
+		if h.NeedsAttention() {
+			score += " " + symbols.Warning
+		}
+		table.Append(
+			fmt.Sprintf("%d", i+1),
+			h.Repository,
+			score,
+			fmt.Sprintf("%d", h.Open),
+			FormatDuration(h.OldestAge),
+			fmt.Sprintf("%d", h.Failing),
+			fmt.Sprintf("%d", h.Conflicting),
+			fmt.Sprintf("%d", h.Security),
+		)
+	}
+	table.Render()
+}
+
+// RenderHealthJSON writes repository health scores as a JSON array, worst first
+func RenderHealthJSON(w io.Writer, repos []health.RepositoryHealth) error {
+	out := make([]jsonRepositoryHealth, 0, len(repos))
+	for _, h := range repos {
+		out = append(out, jsonRepositoryHealth{
+			Repository:       h.Repository,
+			Score:            h.Score,
+			NeedsAttention:   h.NeedsAttention(),
+			Open:             h.Open,
+			Failing:          h.Failing,
+			Conflicting:      h.Conflicting,
+			Security:         h.Security,
+			OldestAgeSeconds: int64(h.OldestAge.Seconds()),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
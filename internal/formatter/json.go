@@ -0,0 +1,72 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/sorter"
+)
+
+// jsonPullRequest is the JSON representation of a pull request
+type jsonPullRequest struct {
+	Repository     string    `json:"repository"`
+	Number         int       `json:"number"`
+	Title          string    `json:"title"`
+	Author         string    `json:"author"`
+	Bot            string    `json:"bot"`
+	CI             string    `json:"ci"`
+	Mergeable      string    `json:"mergeable"`
+	ReviewDecision string    `json:"review_decision"`
+	Labels         []string  `json:"labels"`
+	Version        string    `json:"version"`
+	Dependency     string    `json:"dependency"`
+	UpdateType     string    `json:"update_type"`
+	CreatedAt      time.Time `json:"created_at"`
+	URL            string    `json:"url"`
+	HeadSHA        string    `json:"head_sha"`
+	HeadRef        string    `json:"head_ref"`
+	BaseRef        string    `json:"base_ref"`
+	Additions      int       `json:"additions"`
+	Deletions      int       `json:"deletions"`
+}
+
+// RenderJSON writes pull requests as a JSON array
+// PRs are sorted by the given sort keys, like RenderTable
+func RenderJSON(w io.Writer, prs []models.PullRequest, sortKeys []sorter.Key) error {
+	sorter.Sort(prs, sortKeys)
+
+	out := make([]jsonPullRequest, 0, len(prs))
+	for _, pr := range prs {
+		labels := pr.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		out = append(out, jsonPullRequest{
+			Repository:     pr.Repository,
+			Number:         pr.Number,
+			Title:          pr.Title,
+			Author:         pr.Author,
+			Bot:            string(pr.BotType),
+			CI:             pr.CheckSummary.Status.Name(),
+			Mergeable:      string(pr.MergeableState),
+			ReviewDecision: string(pr.ReviewDecision),
+			Labels:         labels,
+			Version:        pr.Version,
+			Dependency:     pr.Dependency,
+			UpdateType:     string(pr.UpdateType),
+			CreatedAt:      pr.CreatedAt,
+			URL:            pr.URL,
+			HeadSHA:        pr.HeadSHA,
+			HeadRef:        pr.HeadRef,
+			BaseRef:        pr.BaseRef,
+			Additions:      pr.Additions,
+			Deletions:      pr.Deletions,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
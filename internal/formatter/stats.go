@@ -0,0 +1,142 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/swfz/gh-deps/internal/stats"
+)
+
+// jsonGroupStats is the JSON representation of per-repository or per-bot statistics
+type jsonGroupStats struct {
+	Name                   string   `json:"name"`
+	Open                   int      `json:"open"`
+	Merged                 int      `json:"merged"`
+	Closed                 int      `json:"closed"`
+	MergeRatio             *float64 `json:"merge_ratio"`
+	MeanTimeToMergeSeconds int64    `json:"mean_time_to_merge_seconds"`
+	OldestOpenAgeSeconds   int64    `json:"oldest_open_age_seconds"`
+}
+
+// jsonStatsReport is the JSON representation of a statistics report
+type jsonStatsReport struct {
+	Since          time.Time        `json:"since"`
+	GeneratedAt    time.Time        `json:"generated_at"`
+	OpenAgeSeconds map[string]int64 `json:"open_age_seconds"`
+	Total          jsonGroupStats   `json:"total"`
+	Bots           []jsonGroupStats `json:"bots"`
+	Repositories   []jsonGroupStats `json:"repositories"`
+}
+
+// RenderStats writes a statistics report as text.
+// Only the maxRepos repositories with the oldest backlog are listed (0 = all).
+func RenderStats(w io.Writer, report *stats.Report, maxRepos int) {
+	total := report.Total
+	days := int(report.Now.Sub(report.Since).Hours() / 24)
+
+	fmt.Fprintf(w, "Dependency update PRs since %s (%d days)\n\n", report.Since.Local().Format("2006-01-02"), days)
+	fmt.Fprintf(w, "Open: %d  Merged: %d  Closed without merging: %d  Merge ratio: %s\n",
+		total.Open, total.Merged, total.Closed, formatRatio(total.MergeRatio()))
+	fmt.Fprintf(w, "Open PR age: p50 %s  p75 %s  p90 %s  max %s\n",
+		FormatDuration(report.OpenAge.P50), FormatDuration(report.OpenAge.P75),
+		FormatDuration(report.OpenAge.P90), FormatDuration(report.OpenAge.Max))
+	fmt.Fprintf(w, "Mean time to merge: %s\n", FormatDuration(total.MeanTimeToMerge))
+
+	fmt.Fprintf(w, "\nBy bot\n")
+	renderGroupTable(w, "BOT", report.Bots)
+
+	repos := report.Repositories
+	fmt.Fprintf(w, "\nBy repository (oldest backlog first)\n")
+	if maxRepos > 0 && len(repos) > maxRepos {
+		renderGroupTable(w, "REPOSITORY", repos[:maxRepos])
+		fmt.Fprintf(w, "... and %d more repositories\n", len(repos)-maxRepos)
+	} else {
+		renderGroupTable(w, "REPOSITORY", repos)
+	}
+}
+
+// renderGroupTable renders per-group statistics as a table
+func renderGroupTable(w io.Writer, nameHeader string, groups []stats.GroupStats) {
+	table := tablewriter.NewWriter(w)
+	table.Header(nameHeader, "OPEN", "OLDEST OPEN", "MERGED", "CLOSED", "MERGE RATIO", "MEAN TIME TO MERGE")
+	for _, g := range groups {
+		table.Append(
+			g.Name,
+			fmt.Sprintf("%d", g.Open),
+			FormatDuration(g.OldestOpenAge),
+			fmt.Sprintf("%d", g.Merged),
+			fmt.Sprintf("%d", g.Closed),
+			formatRatio(g.MergeRatio()),
+			FormatDuration(g.MeanTimeToMerge),
+		)
+	}
+	table.Render()
+}
+
+// RenderStatsJSON writes a statistics report as JSON
+func RenderStatsJSON(w io.Writer, report *stats.Report) error {
+	out := jsonStatsReport{
+		Since:       report.Since,
+		GeneratedAt: report.Now,
+		OpenAgeSeconds: map[string]int64{
+			"p50": int64(report.OpenAge.P50.Seconds()),
+			"p75": int64(report.OpenAge.P75.Seconds()),
+			"p90": int64(report.OpenAge.P90.Seconds()),
+			"max": int64(report.OpenAge.Max.Seconds()),
+		},
+		Total:        toJSONGroupStats(report.Total),
+		Bots:         make([]jsonGroupStats, 0, len(report.Bots)),
+		Repositories: make([]jsonGroupStats, 0, len(report.Repositories)),
+	}
+	for _, g := range report.Bots {
+		out.Bots = append(out.Bots, toJSONGroupStats(g))
+	}
+	for _, g := range report.Repositories {
+		out.Repositories = append(out.Repositories, toJSONGroupStats(g))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// toJSONGroupStats converts group statistics to their JSON representation
+func toJSONGroupStats(g stats.GroupStats) jsonGroupStats {
+	out := jsonGroupStats{
+		Name:                   g.Name,
+		Open:                   g.Open,
+		Merged:                 g.Merged,
+		Closed:                 g.Closed,
+		MeanTimeToMergeSeconds: int64(g.MeanTimeToMerge.Seconds()),
+		OldestOpenAgeSeconds:   int64(g.OldestOpenAge.Seconds()),
+	}
+	if ratio := g.MergeRatio(); ratio >= 0 {
+		out.MergeRatio = &ratio
+	}
+	return out
+}
+
+// FormatDuration formats a duration compactly (e.g., "45m", "5h", "3.2d")
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	}
+}
+
+// formatRatio formats a ratio as a percentage ("-" when undefined)
+func formatRatio(ratio float64) string {
+	if ratio < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", ratio*100)
+}
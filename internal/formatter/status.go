@@ -0,0 +1,104 @@
+package formatter
+
+import "github.com/swfz/gh-deps/internal/models"
+
+// StatusSymbols are the symbols statuses are rendered with.
+// The table, the text outputs of the commands and the interactive mode share
+// them so that ASCII mode applies everywhere.
+type StatusSymbols struct {
+	Success     string // CI passed
+	Failure     string // CI failed
+	Pending     string // CI running
+	None        string // No CI or unknown state
+	Mergeable   string // PR can be merged
+	Conflicting string // PR has conflicts
+	Unknown     string // Mergeable state not computed yet
+	Warning     string // Prefix of warnings
+	Done        string // Prefix of a succeeded action
+	Failed      string // Prefix of a failed action
+	Running     string // Prefix of an action in progress
+	Arrow       string // Separator of a previous and a current value
+}
+
+// unicodeSymbols are the default emoji and symbols
+var unicodeSymbols = StatusSymbols{
+	Success:     string(models.StatusSuccess),
+	Failure:     string(models.StatusFailure),
+	Pending:     string(models.StatusPending),
+	None:        "-",
+	Mergeable:   "✓",
+	Conflicting: "✗",
+	Unknown:     "?",
+	Warning:     "⚠",
+	Done:        "✓",
+	Failed:      "✗",
+	Running:     "⟳",
+	Arrow:       "→",
+}
+
+// asciiSymbols replace emoji and symbols with words for terminals and
+// screen readers that render them badly
+var asciiSymbols = StatusSymbols{
+	Success:     "pass",
+	Failure:     "fail",
+	Pending:     "pend",
+	None:        "-",
+	Mergeable:   "yes",
+	Conflicting: "no",
+	Unknown:     "?",
+	Warning:     "!",
+	Done:        "ok:",
+	Failed:      "error:",
+	Running:     "...",
+	Arrow:       "->",
+}
+
+// symbols are the symbols in use (see SetASCII)
+var symbols = unicodeSymbols
+
+// SetASCII switches between the default symbols and plain ASCII words
+func SetASCII(enabled bool) {
+	if enabled {
+		symbols = asciiSymbols
+	} else {
+		symbols = unicodeSymbols
+	}
+}
+
+// ASCII reports whether ASCII mode is enabled
+func ASCII() bool {
+	return symbols == asciiSymbols
+}
+
+// Symbols returns the symbols in use
+func Symbols() StatusSymbols {
+	return symbols
+}
+
+// CheckStatus returns the symbol of a CI status
+func CheckStatus(status models.CheckStatus) string {
+	switch status {
+	case models.StatusSuccess:
+		return symbols.Success
+	case models.StatusFailure:
+		return symbols.Failure
+	case models.StatusPending:
+		return symbols.Pending
+	default:
+		return symbols.None
+	}
+}
+
+// MergeableState returns the symbol of a mergeable state
+func MergeableState(state models.MergeableState) string {
+	switch state {
+	case models.MergeableStateMergeable:
+		return symbols.Mergeable
+	case models.MergeableStateConflicting:
+		return symbols.Conflicting
+	case models.MergeableStateUnknown:
+		return symbols.Unknown
+	default:
+		return symbols.None
+	}
+}
@@ -2,79 +2,132 @@ package formatter
 
 import (
 	"fmt"
-	"os"
-	"sort"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/pkg/twwidth"
 	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/sorter"
 )
 
+// TableOptions configures table rendering
+type TableOptions struct {
+	ShowRowNumbers bool         // Prepend a # column (used by interactive mode)
+	SortKeys       []sorter.Key // Sort order of the rows
+	Columns        []Column     // Columns to display (nil = DefaultColumns)
+	Width          int          // Terminal width to fit into (0 = use each column's MaxWidth)
+
+	// Marker returns a change marker for a PR, shown in a leading CHANGE column (nil = no column)
+	Marker func(pr *models.PullRequest) string
+}
+
+// rowNumberColumn is the # column shown in interactive mode
+var rowNumberColumn = Column{Name: "#", Header: "#"}
+
 // RenderTable displays pull requests in a formatted table
-// PRs are sorted by repository name (alphabetical)
+// PRs are sorted by the given sort keys
 // Returns the sorted slice for consistent indexing when interactive mode is enabled
-func RenderTable(prs []models.PullRequest, showRowNumbers bool) []models.PullRequest {
-	// Sort by repository name
-	sort.Slice(prs, func(i, j int) bool {
-		return prs[i].RepoName() < prs[j].RepoName()
-	})
-
-	table := tablewriter.NewWriter(os.Stdout)
-
-	// Set header - add # column if showing row numbers
-	if showRowNumbers {
-		table.Header("#", "REPO", "BOT", "CI", "MERGE", "LABELS", "DATE", "VERSION", "TITLE", "URL")
-	} else {
-		table.Header("REPO", "BOT", "CI", "MERGE", "LABELS", "DATE", "VERSION", "TITLE", "URL")
+func RenderTable(w io.Writer, prs []models.PullRequest, opts TableOptions) []models.PullRequest {
+	sorter.Sort(prs, opts.SortKeys)
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	if opts.Marker != nil {
+		markerColumn := Column{Name: "change", Header: "CHANGE", value: func(pr *models.PullRequest, _ time.Time) string {
+			return opts.Marker(pr)
+		}}
+		columns = append([]Column{markerColumn}, columns...)
+	}
+	if opts.ShowRowNumbers {
+		columns = append([]Column{rowNumberColumn}, columns...)
 	}
 
-	// Add rows
-	for i, pr := range prs {
-		var row []interface{}
-		if showRowNumbers {
-			row = []interface{}{
-				fmt.Sprintf("%d", i+1), // 1-based row number
-				TruncateString(pr.RepoName(), 20),
-				pr.BotType.DisplayName(),
-				string(pr.CheckSummary.Status),
-				formatMergeableState(pr.MergeableState),
-				formatLabels(pr.Labels),
-				pr.FormattedDate(),
-				pr.Version,
-				TruncateWithEllipsis(pr.Title, 60),
-				pr.URL,
-			}
-		} else {
-			row = []interface{}{
-				TruncateString(pr.RepoName(), 20),
-				pr.BotType.DisplayName(),
-				string(pr.CheckSummary.Status),
-				formatMergeableState(pr.MergeableState),
-				formatLabels(pr.Labels),
-				pr.FormattedDate(),
-				pr.Version,
-				TruncateWithEllipsis(pr.Title, 60),
-				pr.URL,
+	// Build cell values first so that widths can be fitted to the content
+	now := time.Now()
+	rows := make([][]string, len(prs))
+	for i := range prs {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			if column.value == nil {
+				row[j] = fmt.Sprintf("%d", i+1) // 1-based row number
+			} else {
+				row[j] = column.value(&prs[i], now)
 			}
 		}
-		table.Append(row...)
+		rows[i] = row
+	}
+
+	widths := fitColumnWidths(columns, rows, opts.Width)
+
+	table := tablewriter.NewWriter(w)
+
+	headers := make([]interface{}, len(columns))
+	for j, column := range columns {
+		headers[j] = column.Header
+	}
+	table.Header(headers...)
+
+	for _, row := range rows {
+		cells := make([]interface{}, len(row))
+		for j, cell := range row {
+			cells[j] = TruncateToWidth(cell, widths[j])
+		}
+		table.Append(cells...)
 	}
 
 	table.Render()
 	return prs
 }
 
-// formatMergeableState returns a visual indicator for mergeable state
-func formatMergeableState(state models.MergeableState) string {
-	switch state {
-	case models.MergeableStateMergeable:
-		return "✓"
-	case models.MergeableStateConflicting:
-		return "✗"
-	case models.MergeableStateUnknown:
-		return "?"
-	default:
-		return "-"
+// fitColumnWidths returns the display width of each column.
+// Columns start at the width of their widest value. With a known terminal
+// width, shrinkable columns (MinWidth > 0) are narrowed, widest first, until
+// the table fits; otherwise each column is capped at its MaxWidth.
+func fitColumnWidths(columns []Column, rows [][]string, termWidth int) []int {
+	widths := make([]int, len(columns))
+	for j, column := range columns {
+		widths[j] = twwidth.Width(column.Header)
+		for _, row := range rows {
+			if w := twwidth.Width(row[j]); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	if termWidth <= 0 {
+		for j, column := range columns {
+			if column.MaxWidth > 0 && widths[j] > column.MaxWidth {
+				widths[j] = column.MaxWidth
+			}
+		}
+		return widths
+	}
+
+	// Each column is padded with a space on both sides plus a border
+	total := 1
+	for _, w := range widths {
+		total += w + 3
 	}
+
+	for total > termWidth {
+		widest := -1
+		for j, column := range columns {
+			if column.MinWidth > 0 && widths[j] > column.MinWidth && (widest < 0 || widths[j] > widths[widest]) {
+				widest = j
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	return widths
 }
 
 // formatLabels formats PR labels for display
@@ -82,13 +135,5 @@ func formatLabels(labels []string) string {
 	if len(labels) == 0 {
 		return "-"
 	}
-	// Join labels with comma and truncate if too long
-	labelsStr := ""
-	for i, label := range labels {
-		if i > 0 {
-			labelsStr += ","
-		}
-		labelsStr += label
-	}
-	return TruncateWithEllipsis(labelsStr, 30)
+	return strings.Join(labels, ",")
 }
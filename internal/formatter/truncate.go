@@ -1,6 +1,11 @@
 package formatter
 
-import "unicode/utf8"
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/olekukonko/tablewriter/pkg/twwidth"
+)
 
 // TruncateString truncates a string to maxLen characters
 // Handles UTF-8 properly by counting runes, not bytes
@@ -26,3 +31,64 @@ func TruncateWithEllipsis(s string, maxLen int) string {
 
 	return string(runes[:maxLen-3]) + "..."
 }
+
+// TruncateToWidth truncates a string to a terminal display width and adds "..." if truncated
+// Wide characters (e.g., CJK, emoji) count as two columns
+func TruncateToWidth(s string, maxWidth int) string {
+	if twwidth.Width(s) <= maxWidth {
+		return s
+	}
+
+	suffix := "..."
+	if maxWidth <= len(suffix) {
+		suffix = ""
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		w := twwidth.Width(string(r))
+		if width+w > maxWidth-len(suffix) {
+			break
+		}
+		b.WriteRune(r)
+		width += w
+	}
+	return b.String() + suffix
+}
+
+// PadToWidth truncates or pads a string with spaces to exactly a terminal display width
+func PadToWidth(s string, width int) string {
+	s = TruncateToWidth(s, width)
+	if pad := width - twwidth.Width(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// CutToWidth returns the part of a string between two display columns,
+// e.g., for horizontal scrolling. Wide characters cut in half are replaced
+// with spaces so that the result is never wider than width.
+func CutToWidth(s string, start, width int) string {
+	end := start + width
+
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if col >= end {
+			break
+		}
+		w := twwidth.Width(string(r))
+		switch {
+		case col+w <= start:
+			// Left of the visible part
+		case col < start || col+w > end:
+			// Cut in half at either edge
+			b.WriteString(strings.Repeat(" ", min(col+w, end)-max(col, start)))
+		default:
+			b.WriteRune(r)
+		}
+		col += w
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Penalty weights. A repository starts at 100 points and loses points for
+// each signal, with every signal capped so that no single one dominates.
+const (
+	openPenaltyPerPR        = 1.5  // Points per open PR
+	openPenaltyMax          = 30.0 // Cap for the open PR count
+	agePenaltyPerDay        = 0.5  // Points per day of the oldest open PR
+	agePenaltyMax           = 25.0 // Cap for the oldest PR age (reached at 50 days)
+	failingPenaltyMax       = 20.0 // Points when every PR fails CI
+	conflictingPenaltyMax   = 15.0 // Points when every PR is conflicting
+	securityPenaltyPerPR    = 10.0 // Points per open security update
+	securityPenaltyMax      = 30.0 // Cap for security updates
+	maxScore                = 100
+	attentionScoreThreshold = 60 // Scores below this need attention
+)
+
+// RepositoryHealth is the dependency health of a repository
+type RepositoryHealth struct {
+	Repository  string        // Full repository name (owner/repo)
+	Score       int           // 0 (worst) to 100 (no open dependency PRs)
+	Open        int           // Open dependency PRs
+	Failing     int           // PRs with failing CI
+	Conflicting int           // PRs with conflicts
+	Security    int           // Open security updates
+	OldestAge   time.Duration // Age of the oldest open PR
+}
+
+// NeedsAttention reports whether the score is low enough to need an owner's attention
+func (h *RepositoryHealth) NeedsAttention() bool {
+	return h.Score < attentionScoreThreshold
+}
+
+// Compute returns the health of each repository with open PRs, worst first.
+// Ties are ordered by the oldest PR age and then by name.
+func Compute(prs []models.PullRequest, now time.Time) []RepositoryHealth {
+	byRepo := make(map[string]*RepositoryHealth)
+	for _, pr := range prs {
+		h, ok := byRepo[pr.Repository]
+		if !ok {
+			h = &RepositoryHealth{Repository: pr.Repository}
+			byRepo[pr.Repository] = h
+		}
+
+		h.Open++
+		if pr.CheckSummary.Status == models.StatusFailure {
+			h.Failing++
+		}
+		if pr.MergeableState == models.MergeableStateConflicting {
+			h.Conflicting++
+		}
+		if pr.IsSecurityUpdate() {
+			h.Security++
+		}
+		if age := pr.Age(now); age > h.OldestAge {
+			h.OldestAge = age
+		}
+	}
+
+	result := make([]RepositoryHealth, 0, len(byRepo))
+	for _, h := range byRepo {
+		h.Score = score(h)
+		result = append(result, *h)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score < result[j].Score
+		}
+		if result[i].OldestAge != result[j].OldestAge {
+			return result[i].OldestAge > result[j].OldestAge
+		}
+		return result[i].Repository < result[j].Repository
+	})
+
+	return result
+}
+
+// score computes the health score from the repository's PR counts
+func score(h *RepositoryHealth) int {
+	if h.Open == 0 {
+		return maxScore
+	}
+
+	open := float64(h.Open)
+	penalty := math.Min(open*openPenaltyPerPR, openPenaltyMax) +
+		math.Min(h.OldestAge.Hours()/24*agePenaltyPerDay, agePenaltyMax) +
+		float64(h.Failing)/open*failingPenaltyMax +
+		float64(h.Conflicting)/open*conflictingPenaltyMax +
+		math.Min(float64(h.Security)*securityPenaltyPerPR, securityPenaltyMax)
+
+	return int(math.Max(0, math.Round(maxScore-penalty)))
+}
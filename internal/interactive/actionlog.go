@@ -0,0 +1,294 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/audit"
+	"github.com/swfz/gh-deps/internal/formatter"
+)
+
+// undoKind is how a succeeded action is reverted
+type undoKind int
+
+const (
+	undoDeleteComment undoKind = iota // Delete the posted comment (comments and Dependabot rebases)
+	undoRestoreBody                   // Restore the PR body before the rebase checkbox was checked (Renovate rebases)
+	undoDismissReview                 // Dismiss the approving review
+)
+
+// undoAction reverts a succeeded action
+type undoAction struct {
+	kind      undoKind
+	commentID int    // Comment to delete
+	reviewID  int64  // Review to dismiss
+	body      string // PR body to restore
+}
+
+func (u undoAction) String() string {
+	switch u.kind {
+	case undoRestoreBody:
+		return "restore body"
+	case undoDismissReview:
+		return "dismiss review"
+	default:
+		return "delete comment"
+	}
+}
+
+// dismissMessage is the message of reviews dismissed by undoing an approval
+const dismissMessage = "Approval withdrawn from gh-deps"
+
+// actionEntry is an action of this session in the action log
+type actionEntry struct {
+	audit.Entry
+	undo    *undoAction // How to revert the action (nil = cannot be undone)
+	undoing bool        // Whether the undo is in flight
+	undone  bool        // Whether the action was undone
+}
+
+// undoResultMsg represents the result of undoing an action
+type undoResultMsg struct {
+	index   int // Index of the undone entry in the action log
+	success bool
+	message string
+}
+
+// recordAction adds an action to the action log and appends it to the audit log.
+// Failing to write the audit log is shown in the action log without stopping the TUI.
+func (m *model) recordAction(entry audit.Entry, undo *undoAction) {
+	entry.Time = time.Now()
+	if !entry.Success {
+		undo = nil
+	}
+	if undo != nil {
+		entry.CommentID = undo.commentID
+		entry.ReviewID = undo.reviewID
+	}
+	m.actions = append(m.actions, actionEntry{Entry: entry, undo: undo})
+
+	if m.audit != nil {
+		if err := m.audit.Append(entry); err != nil {
+			m.auditErr = err
+		}
+	}
+}
+
+// updateActionLog handles key presses in the action log
+func (m model) updateActionLog(key string) (tea.Model, tea.Cmd) {
+	switch m.keys.action(modeActions, key) {
+	case "quit":
+		m.done = true
+		return m, tea.Quit
+
+	case "help":
+		m.openHelp()
+
+	case "switch_view", "back":
+		m.view = viewPullRequests
+
+	case "up":
+		m.moveActionCursor(-1)
+
+	case "down":
+		m.moveActionCursor(1)
+
+	case "undo":
+		return m, m.startUndo()
+	}
+
+	return m, nil
+}
+
+// moveActionCursor moves the cursor of the action log by delta, keeping it within the entries
+func (m *model) moveActionCursor(delta int) {
+	m.actionCursor += delta
+	if m.actionCursor >= len(m.actions) {
+		m.actionCursor = len(m.actions) - 1
+	}
+	if m.actionCursor < 0 {
+		m.actionCursor = 0
+	}
+}
+
+// startUndo reverts the action under the cursor in the background
+func (m *model) startUndo() tea.Cmd {
+	if len(m.actions) == 0 {
+		return nil
+	}
+
+	// The newest entry is shown first
+	index := len(m.actions) - 1 - m.actionCursor
+	entry := &m.actions[index]
+
+	m.messageType = "error"
+	switch {
+	case !entry.Success:
+		m.message = fmt.Sprintf("The %s of PR #%d failed, there is nothing to undo", entry.Action, entry.Number)
+		return nil
+	case entry.undone:
+		m.message = fmt.Sprintf("The %s of PR #%d was already undone", entry.Action, entry.Number)
+		return nil
+	case entry.undoing:
+		m.message = fmt.Sprintf("The %s of PR #%d is already being undone", entry.Action, entry.Number)
+		return nil
+	case entry.undo == nil:
+		m.message = fmt.Sprintf("The %s of PR #%d cannot be undone", entry.Action, entry.Number)
+		return nil
+	}
+
+	entry.undoing = true
+	m.message = fmt.Sprintf("Undoing the %s of PR #%d in %s...", entry.Action, entry.Number, entry.Repository)
+	m.messageType = ""
+
+	repository, number, undo := entry.Repository, entry.Number, *entry.undo
+	return func() tea.Msg {
+		success, message := m.executeUndo(repository, number, undo)
+		return undoResultMsg{index: index, success: success, message: message}
+	}
+}
+
+// executeUndo reverts an action on a PR and returns whether it succeeded with a status message
+func (m *model) executeUndo(repository string, number int, undo undoAction) (bool, string) {
+	owner, repo, err := api.ParseRepository(repository)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid repository format: %v", err)
+	}
+
+	switch undo.kind {
+	case undoRestoreBody:
+		// Renovate rewrites the body once it has processed the rebase; restoring the
+		// body from before the rebase would then overwrite it with stale content
+		current, err := m.client.FetchPullRequestBody(m.ctx, owner, repo, number)
+		if err != nil {
+			return false, fmt.Sprintf("Failed to fetch PR body: %v", err)
+		}
+		checked, err := api.CheckRenovateRebaseCheckbox(undo.body)
+		if err != nil || current != checked {
+			return false, fmt.Sprintf("PR #%d in %s was already processed by Renovate, cannot undo", number, repository)
+		}
+		if _, err := m.client.UpdatePullRequestBody(m.ctx, owner, repo, number, undo.body); err != nil {
+			return false, fmt.Sprintf("Failed to restore PR body: %v", err)
+		}
+		return true, fmt.Sprintf("Restored the body of PR #%d in %s (rebase checkbox unchecked)", number, repository)
+	case undoDismissReview:
+		if err := m.client.DismissReview(m.ctx, owner, repo, number, undo.reviewID, dismissMessage); err != nil {
+			return false, fmt.Sprintf("Failed to dismiss review: %v", err)
+		}
+		return true, fmt.Sprintf("Dismissed the approval of PR #%d in %s", number, repository)
+	default:
+		if err := m.client.DeleteComment(m.ctx, owner, repo, undo.commentID); err != nil {
+			return false, fmt.Sprintf("Failed to delete comment: %v", err)
+		}
+		return true, fmt.Sprintf("Deleted the comment on PR #%d in %s", number, repository)
+	}
+}
+
+// handleUndoResult marks the entry as undone and logs the undo as an action of its own
+func (m model) handleUndoResult(msg undoResultMsg) (tea.Model, tea.Cmd) {
+	if msg.index >= len(m.actions) {
+		return m, nil
+	}
+
+	entry := &m.actions[msg.index]
+	entry.undoing = false
+	entry.undone = msg.success
+	undone := audit.Entry{
+		Repository: entry.Repository, Number: entry.Number, Title: entry.Title,
+		Action: "undo " + entry.Action, Success: msg.success, Message: msg.message,
+		CommentID: entry.CommentID, ReviewID: entry.ReviewID,
+	}
+
+	// The new entry is listed first, so keep the cursor on the same entry
+	m.recordAction(undone, nil)
+	m.actionCursor++
+
+	m.message = msg.message
+	m.messageType = "success"
+	if !msg.success {
+		m.messageType = "error"
+	}
+	return m, nil
+}
+
+// renderActionLog renders the actions of this session, newest first
+func (m model) renderActionLog() string {
+	var b strings.Builder
+
+	listHeader := fmt.Sprintf("%-8s %-32s %-16s %-14s %s", "TIME", "PR", "ACTION", "UNDO", "RESULT")
+	b.WriteString(dimStyle.Render(listHeader) + "\n")
+	b.WriteString(strings.Repeat("─", m.width) + "\n")
+
+	if len(m.actions) == 0 {
+		b.WriteString("\n" + dimStyle.Render("  No actions yet. Merges, rebases, approvals, comments and re-runs are listed here") + "\n")
+	}
+
+	cursor := m.actionCursor
+	if cursor >= len(m.actions) {
+		cursor = len(m.actions) - 1
+	}
+
+	maxVisible := m.getPageSize()
+	startIdx := cursor - maxVisible/2
+	if startIdx < 0 {
+		startIdx = 0
+	}
+	endIdx := startIdx + maxVisible
+	if endIdx > len(m.actions) {
+		endIdx = len(m.actions)
+		startIdx = endIdx - maxVisible
+		if startIdx < 0 {
+			startIdx = 0
+		}
+	}
+
+	for i := startIdx; i < endIdx; i++ {
+		entry := m.actions[len(m.actions)-1-i]
+		result := formatter.Symbols().Done
+		if !entry.Success {
+			result = formatter.Symbols().Failed
+		}
+		line := fmt.Sprintf("%-8s %s %-16s %-14s %s %s",
+			entry.Time.Format("15:04:05"), formatter.PadToWidth(truncate(fmt.Sprintf("%s#%d", entry.Repository, entry.Number), 32), 32),
+			entry.Action, formatUndoState(entry), result, entry.Message)
+
+		switch {
+		case i == cursor:
+			b.WriteString(selectedStyle.Render(icon.cursor+" "+line) + "\n")
+		case !entry.Success:
+			b.WriteString(errorStyle.Render("  "+line) + "\n")
+		case entry.undone:
+			b.WriteString(dimStyle.Render("  "+line) + "\n")
+		default:
+			b.WriteString(normalStyle.Render("  "+line) + "\n")
+		}
+	}
+
+	footer := fmt.Sprintf("  %d/%d actions  |  %s to undo, %s for help", cursor+1, len(m.actions),
+		m.keys.hint(modeActions, "undo"), m.keys.hint(modeActions, "help"))
+	if m.audit != nil {
+		footer += "  |  Audit log: " + m.audit.Path()
+	}
+	b.WriteString("\n" + dimStyle.Render(footer) + "\n")
+	if m.auditErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("  %s %v", formatter.Symbols().Warning, m.auditErr)) + "\n")
+	}
+	return b.String()
+}
+
+// formatUndoState describes whether and how an entry can be undone
+func formatUndoState(entry actionEntry) string {
+	switch {
+	case entry.undone:
+		return "undone"
+	case entry.undoing:
+		return "undoing..."
+	case entry.undo != nil:
+		return entry.undo.String()
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,454 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/audit"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// bulkActions lists the operations available for selected PRs, in the order Tab cycles through them
+var bulkActions = []operation{opMerge, opRebase, opApprove, opComment}
+
+// bulkStatus is the progress of a single PR in a bulk action
+type bulkStatus int
+
+const (
+	bulkQueued bulkStatus = iota
+	bulkRunning
+	bulkSucceeded
+	bulkFailed
+)
+
+// bulkResult tracks the progress of a single PR in a bulk action
+type bulkResult struct {
+	pr      models.PullRequest
+	status  bulkStatus
+	message string
+}
+
+// bulkOperation is a bulk action being confirmed or run
+type bulkOperation struct {
+	action  operation
+	comment string       // Comment body (comment action only)
+	hidden  int          // Selected PRs hidden by the search filter or status tab
+	started bool         // Whether the action was confirmed
+	results []bulkResult // One entry per selected PR, in list order
+	next    int          // Index of the next PR to process
+}
+
+// finished reports whether every PR has been processed
+func (b *bulkOperation) finished() bool {
+	return b.started && b.next >= len(b.results)
+}
+
+// counts returns the number of succeeded and failed PRs
+func (b *bulkOperation) counts() (succeeded, failed int) {
+	for _, r := range b.results {
+		switch r.status {
+		case bulkSucceeded:
+			succeeded++
+		case bulkFailed:
+			failed++
+		}
+	}
+	return succeeded, failed
+}
+
+// bulkResultMsg represents the result of a bulk action on a single PR
+type bulkResultMsg struct {
+	index   int
+	success bool
+	message string
+	tracked bool        // Whether the PR was marked as busy for this action
+	undo    *undoAction // How to revert the action (nil = cannot be undone)
+}
+
+// toggleSelection selects or deselects the PR under the cursor and moves down.
+// On a repository header of the tree view, it selects the listed PRs of the
+// repository, or deselects them if they are all selected.
+func (m *model) toggleSelection() {
+	row, ok := m.currentRow()
+	if !ok {
+		return
+	}
+
+	if row.header {
+		var ids []PRIdentifier
+		all := true
+		for _, pr := range m.filtered {
+			if pr.Repository == row.repo {
+				ids = append(ids, identify(pr))
+				all = all && m.selected[identify(pr)]
+			}
+		}
+		for _, id := range ids {
+			if all {
+				delete(m.selected, id)
+			} else {
+				m.selected[id] = true
+			}
+		}
+	} else {
+		id := identify(row.pr)
+		if m.selected[id] {
+			delete(m.selected, id)
+		} else {
+			m.selected[id] = true
+		}
+	}
+
+	if m.cursor < len(m.rows)-1 {
+		m.cursor++
+	}
+}
+
+// selectAllVisible selects every PR matching the current search filter and tab
+func (m *model) selectAllVisible() {
+	for _, pr := range m.filtered {
+		m.selected[identify(pr)] = true
+	}
+}
+
+// invertSelection inverts the selection of the PRs matching the current search filter and tab
+func (m *model) invertSelection() {
+	for _, pr := range m.filtered {
+		id := identify(pr)
+		if m.selected[id] {
+			delete(m.selected, id)
+		} else {
+			m.selected[id] = true
+		}
+	}
+}
+
+// pruneSelection forgets selected PRs that are no longer listed
+func (m *model) pruneSelection() {
+	listed := make(map[PRIdentifier]bool, len(m.prs))
+	for _, pr := range m.prs {
+		listed[identify(pr)] = true
+	}
+	for id := range m.selected {
+		if !listed[id] {
+			delete(m.selected, id)
+		}
+	}
+}
+
+// openBulkConfirm shows the confirmation modal for the selected PRs
+func (m *model) openBulkConfirm() {
+	visible := make(map[PRIdentifier]bool, len(m.filtered))
+	for _, pr := range m.filtered {
+		visible[identify(pr)] = true
+	}
+
+	bulk := &bulkOperation{action: opMerge}
+	for _, pr := range m.prs {
+		id := identify(pr)
+		if !m.selected[id] {
+			continue
+		}
+		if !visible[id] {
+			bulk.hidden++
+		}
+		bulk.results = append(bulk.results, bulkResult{pr: pr})
+	}
+	if len(bulk.results) > 0 {
+		m.bulk = bulk
+	}
+}
+
+// updateBulkConfirm handles key presses in the bulk confirmation modal
+func (m model) updateBulkConfirm(key string) (tea.Model, tea.Cmd) {
+	bulk := m.bulk
+
+	// Printable keys are typed into the comment
+	if bulk.action == opComment {
+		switch {
+		case key == "space":
+			bulk.comment += " "
+			return m, nil
+		case key == "backspace":
+			if len(bulk.comment) > 0 {
+				runes := []rune(bulk.comment)
+				bulk.comment = string(runes[:len(runes)-1])
+			}
+			return m, nil
+		case len([]rune(key)) == 1:
+			bulk.comment += key
+			return m, nil
+		}
+	}
+
+	switch action := m.keys.action(modeConfirm, key); action {
+	case "cancel":
+		m.bulk = nil
+
+	case "next_action", "prev_action":
+		step := 1
+		if action == "prev_action" {
+			step = len(bulkActions) - 1
+		}
+		for i, action := range bulkActions {
+			if action == bulk.action {
+				bulk.action = bulkActions[(i+step)%len(bulkActions)]
+				break
+			}
+		}
+
+	case "confirm":
+		if bulk.action == opComment && strings.TrimSpace(bulk.comment) == "" {
+			return m, nil
+		}
+		bulk.started = true
+		return m, m.runBulkStep()
+	}
+
+	return m, nil
+}
+
+// runBulkStep starts the bulk action on the next PR
+func (m *model) runBulkStep() tea.Cmd {
+	bulk := m.bulk
+	index := bulk.next
+	bulk.results[index].status = bulkRunning
+	pr := bulk.results[index].pr
+	action, comment := bulk.action, bulk.comment
+
+	// PRs with an operation in flight are skipped
+	id := identify(pr)
+	if current, busy := m.operations[id]; busy {
+		message := fmt.Sprintf("PR #%d is already %s", pr.Number, current.progress())
+		return func() tea.Msg {
+			return bulkResultMsg{index: index, message: message}
+		}
+	}
+	m.operations[id] = action
+
+	return func() tea.Msg {
+		success, message, undo := m.executeOperation(pr, action, comment)
+		return bulkResultMsg{index: index, success: success, message: message, tracked: true, undo: undo}
+	}
+}
+
+// handleBulkResult records the result of a PR and continues with the next one
+func (m model) handleBulkResult(msg bulkResultMsg) (tea.Model, tea.Cmd) {
+	// Results only belong to a running bulk action
+	bulk := m.bulk
+	if bulk == nil || !bulk.started || msg.index >= len(bulk.results) {
+		return m, nil
+	}
+
+	result := &bulk.results[msg.index]
+	if msg.tracked {
+		delete(m.operations, identify(result.pr))
+	}
+	result.message = msg.message
+	result.status = bulkFailed
+	bulk.next = msg.index + 1
+
+	entry := audit.Entry{
+		Repository: result.pr.Repository, Number: result.pr.Number, Title: result.pr.Title,
+		Action: bulk.action.String(), Success: msg.success, Message: msg.message,
+	}
+	if bulk.action == opComment {
+		entry.Comment = bulk.comment
+	}
+	m.recordAction(entry, msg.undo)
+
+	var cmds []tea.Cmd
+	if msg.success {
+		result.status = bulkSucceeded
+		delete(m.selected, identify(result.pr))
+
+		if bulk.action == opMerge {
+			m.removePR(identify(result.pr))
+		}
+
+		// Poll the repository to pick up the new state
+		if _, isPolling := m.pollingRepos[result.pr.Repository]; !isPolling {
+			cmds = append(cmds, m.startPolling(result.pr.Repository, bulk.action.pollBackoff()))
+		}
+	}
+
+	if bulk.finished() {
+		succeeded, failed := bulk.counts()
+		m.message = fmt.Sprintf("Bulk %s: %d succeeded, %d failed", bulk.action, succeeded, failed)
+		m.messageType = "success"
+		if failed > 0 {
+			m.messageType = "error"
+		}
+	} else {
+		cmds = append(cmds, m.runBulkStep())
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// bulkWarnings summarizes what may go wrong when applying the action to the PRs
+func bulkWarnings(bulk *bulkOperation) []string {
+	var conflicting, failing, pending, noRebase, approved int
+	for _, r := range bulk.results {
+		pr := r.pr
+		if pr.MergeableState == models.MergeableStateConflicting {
+			conflicting++
+		}
+		switch pr.CheckSummary.Status {
+		case models.StatusFailure:
+			failing++
+		case models.StatusPending:
+			pending++
+		}
+		if !pr.BotType.SupportsRebase() {
+			noRebase++
+		}
+		if pr.ReviewDecision == models.ReviewDecisionApproved {
+			approved++
+		}
+	}
+
+	var warnings []string
+	add := func(count int, format string) {
+		if count > 0 {
+			warnings = append(warnings, fmt.Sprintf(format, count))
+		}
+	}
+	switch bulk.action {
+	case opMerge:
+		add(conflicting, "%d PR(s) have conflicts and will be skipped")
+		add(failing, "%d PR(s) have failing CI checks")
+		add(pending, "%d PR(s) have pending CI checks")
+	case opRebase:
+		add(noRebase, "%d PR(s) have no bot rebase support and will be skipped")
+	case opApprove:
+		add(approved, "%d PR(s) are already approved")
+		add(failing, "%d PR(s) have failing CI checks")
+	case opComment:
+		if strings.TrimSpace(bulk.comment) == "" {
+			warnings = append(warnings, "Type a comment to post")
+		}
+	}
+	add(bulk.hidden, "%d selected PR(s) are hidden by the search filter or status tab")
+	return warnings
+}
+
+// bulkModalWidth is the inner width of the bulk confirmation modal
+const bulkModalWidth = 61
+
+// bulkModalMaxPRs is the number of PRs listed in the bulk confirmation modal
+const bulkModalMaxPRs = 8
+
+// renderBulkConfirm renders the confirmation modal of a bulk action
+func (m model) renderBulkConfirm() string {
+	bulk := m.bulk
+	border := strings.Repeat("═", bulkModalWidth+2)
+	line := func(s string) string {
+		return "║ " + formatter.PadToWidth(s, bulkModalWidth) + " ║\n"
+	}
+
+	var modal strings.Builder
+	modal.WriteString("\n╔" + border + "╗\n")
+	modal.WriteString(line(fmt.Sprintf("BULK %s: %d PRs", strings.ToUpper(bulk.action.String()), len(bulk.results))))
+	modal.WriteString("╠" + border + "╣\n")
+
+	var tabs []string
+	for _, action := range bulkActions {
+		if action == bulk.action {
+			tabs = append(tabs, "["+action.String()+"]")
+		} else {
+			tabs = append(tabs, " "+action.String()+" ")
+		}
+	}
+	modal.WriteString(line("Action: " + strings.Join(tabs, " ") + fmt.Sprintf("  (%s to change)", m.keys.hint(modeConfirm, "next_action"))))
+	if bulk.action == opComment {
+		modal.WriteString(line("Comment: " + bulk.comment + "█"))
+	}
+	modal.WriteString("╠" + border + "╣\n")
+
+	for i, r := range bulk.results {
+		if i == bulkModalMaxPRs {
+			modal.WriteString(line(fmt.Sprintf("... and %d more", len(bulk.results)-i)))
+			break
+		}
+		modal.WriteString(line(fmt.Sprintf("%s#%d %s", r.pr.Repository, r.pr.Number, r.pr.Title)))
+	}
+
+	if warnings := bulkWarnings(bulk); len(warnings) > 0 {
+		modal.WriteString("╠" + border + "╣\n")
+		for _, w := range warnings {
+			modal.WriteString(line(formatter.Symbols().Warning + " " + w))
+		}
+	}
+
+	modal.WriteString(line(""))
+	if bulk.action == opComment {
+		modal.WriteString(line(fmt.Sprintf("Post this comment? (%s to confirm, %s to cancel)",
+			m.keys.hint(modeConfirm, "confirm"), m.keys.hint(modeConfirm, "cancel"))))
+	} else {
+		modal.WriteString(line(fmt.Sprintf("%s these PRs? (%s to confirm, %s to cancel)", capitalize(bulk.action.String()),
+			m.keys.hint(modeConfirm, "confirm"), m.keys.hint(modeConfirm, "cancel"))))
+	}
+	modal.WriteString("╚" + border + "╝\n")
+
+	return selectedStyle.Render(modal.String())
+}
+
+// bulkProgressMaxLines is the number of progress lines shown while a bulk action runs
+const bulkProgressMaxLines = 10
+
+// renderBulkProgress renders one progress line per PR of a running or finished bulk action
+func (m model) renderBulkProgress() string {
+	bulk := m.bulk
+	succeeded, failed := bulk.counts()
+
+	var b strings.Builder
+	status := fmt.Sprintf("Bulk %s: %d/%d done", bulk.action, succeeded+failed, len(bulk.results))
+	if bulk.finished() {
+		status += " (press any key to dismiss)"
+	}
+	b.WriteString("\n" + dimStyle.Render("  "+status) + "\n")
+
+	// Keep the PR being processed in view
+	start := bulk.next - bulkProgressMaxLines/2
+	if start > len(bulk.results)-bulkProgressMaxLines {
+		start = len(bulk.results) - bulkProgressMaxLines
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + bulkProgressMaxLines
+	if end > len(bulk.results) {
+		end = len(bulk.results)
+	}
+
+	for _, r := range bulk.results[start:end] {
+		name := fmt.Sprintf("%s#%d", r.pr.Repository, r.pr.Number)
+		switch r.status {
+		case bulkQueued:
+			b.WriteString(dimStyle.Render("  · "+name+" queued") + "\n")
+		case bulkRunning:
+			b.WriteString(pollingStyle.Render("  "+formatter.Symbols().Running+" "+name+" running...") + "\n")
+		case bulkSucceeded:
+			b.WriteString(successStyle.Render("  "+formatter.Symbols().Done+" "+name) + " " + r.message + "\n")
+		case bulkFailed:
+			b.WriteString(errorStyle.Render("  "+formatter.Symbols().Failed+" "+name) + " " + r.message + "\n")
+		}
+	}
+	return b.String()
+}
+
+// identify returns the identifier of a PR
+func identify(pr models.PullRequest) PRIdentifier {
+	return PRIdentifier{Repository: pr.Repository, Number: pr.Number}
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,280 @@
+package interactive
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/glamour/v2"
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// detailState holds the detail view of a single PR
+type detailState struct {
+	pager
+	pr      models.PullRequest
+	detail  *models.PullRequestDetail // nil until loaded
+	err     error                     // Error of the last fetch
+	loading bool                      // Whether the details are being fetched
+}
+
+// detailLoadedMsg represents the result of fetching the details of a PR
+type detailLoadedMsg struct {
+	id     PRIdentifier
+	detail *models.PullRequestDetail
+	err    error
+}
+
+// openDetail shows the detail view of the PR under the cursor and fetches its details
+func (m *model) openDetail() tea.Cmd {
+	pr, ok := m.currentPR()
+	if !ok {
+		return nil
+	}
+	m.detail = &detailState{pr: pr}
+	return m.loadDetail()
+}
+
+// loadDetail fetches the details of the PR in the detail view
+func (m *model) loadDetail() tea.Cmd {
+	state := m.detail
+	state.loading = true
+	state.err = nil
+	m.renderDetail()
+
+	pr := state.pr
+	return func() tea.Msg {
+		owner, repo, err := api.ParseRepository(pr.Repository)
+		if err != nil {
+			return detailLoadedMsg{id: identify(pr), err: err}
+		}
+		detail, err := m.client.FetchPullRequestDetail(m.ctx, owner, repo, pr.Number)
+		return detailLoadedMsg{id: identify(pr), detail: detail, err: err}
+	}
+}
+
+// handleDetailLoaded stores fetched details if the detail view still shows the PR
+func (m model) handleDetailLoaded(msg detailLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.detail == nil || identify(m.detail.pr) != msg.id {
+		return m, nil
+	}
+	m.detail.loading = false
+	m.detail.err = msg.err
+	if msg.err == nil {
+		m.detail.detail = msg.detail
+	}
+	m.renderDetail()
+	return m, nil
+}
+
+// updateDetailView handles key presses in the detail view
+func (m model) updateDetailView(key string) (tea.Model, tea.Cmd) {
+	state := m.detail
+	action := m.keys.action(modeDetail, key)
+	if state.handleAction(action, m.pagerPageSize()) {
+		return m, nil
+	}
+
+	switch action {
+	case "quit":
+		m.done = true
+		return m, tea.Quit
+
+	case "help":
+		m.openHelp()
+
+	case "back":
+		m.detail = nil
+
+	case "diff":
+		return m, m.openDiff(state.pr)
+
+	case "open":
+		if err := openBrowser(state.pr.URL); err != nil {
+			m.message = fmt.Sprintf("Failed to open browser: %v", err)
+			m.messageType = "error"
+		}
+
+	case "refresh":
+		if !state.loading {
+			return m, m.loadDetail()
+		}
+	}
+
+	return m, nil
+}
+
+// pagerPageSize returns the number of content lines shown in the detail and diff views
+func (m model) pagerPageSize() int {
+	size := m.height - 8 // Reserve space for header, tabs and footer
+	if size < 5 {
+		size = 5
+	}
+	return size
+}
+
+// renderDetailView renders the visible part of the detail view
+func (m model) renderDetailView() string {
+	state := m.detail
+	lines, end := state.visible(m.pagerPageSize())
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+
+	footer := fmt.Sprintf("  Lines %d-%d of %d  |  %s/%s to scroll, %s for diff, %s to open in browser, %s for help, %s to go back",
+		state.offset+1, end, len(state.lines), m.keys.hint(modeDetail, "down"), m.keys.hint(modeDetail, "up"), m.keys.hint(modeDetail, "diff"),
+		m.keys.hint(modeDetail, "open"), m.keys.hint(modeDetail, "help"), m.keys.hint(modeDetail, "back"))
+	b.WriteString("\n" + dimStyle.Render(footer) + "\n")
+	return b.String()
+}
+
+// renderDetail renders the content of the detail view for the current width
+func (m *model) renderDetail() {
+	state := m.detail
+	pr := state.pr
+	width := m.width - 4
+	if width < 40 {
+		width = 40
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%s#%d", pr.Repository, pr.Number)) + " " + pr.Title + "\n")
+	b.WriteString(fmt.Sprintf("  Bot: %s  Version: %s  CI: %s  Mergeable: %s  Review: %s\n",
+		pr.BotType.DisplayName(), orDash(pr.Version), formatter.CheckStatus(pr.CheckSummary.Status),
+		formatter.MergeableState(pr.MergeableState), orDash(string(pr.ReviewDecision))))
+	b.WriteString(fmt.Sprintf("  Branch: %s %s %s  Changes: +%d -%d  Created: %s (%s ago)\n",
+		pr.HeadRef, formatter.Symbols().Arrow, pr.BaseRef, pr.Additions, pr.Deletions, pr.FormattedDate(),
+		formatter.FormatDuration(pr.Age(time.Now()))))
+	b.WriteString("  " + dimStyle.Render(pr.URL) + "\n")
+
+	writeDetailSection(&b, "Description")
+	b.WriteString(renderMarkdown(pr.Body, width))
+
+	switch {
+	case state.loading:
+		b.WriteString("\n" + dimStyle.Render("  "+formatter.Symbols().Running+" Loading checks, files, reviews and comments...") + "\n")
+	case state.err != nil:
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("  %s Failed to load details: %v", formatter.Symbols().Failed, state.err)) + "\n")
+	case state.detail != nil:
+		writeDetailSections(&b, state.detail, width)
+	}
+
+	state.lines = strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	state.scroll(0, m.pagerPageSize())
+}
+
+// writeDetailSections writes the checks, files, reviews and comments of a PR
+func writeDetailSections(b *strings.Builder, detail *models.PullRequestDetail, width int) {
+	writeDetailSection(b, fmt.Sprintf("Checks (%d)", len(detail.Checks)))
+	if len(detail.Checks) == 0 {
+		b.WriteString(dimStyle.Render("  No checks") + "\n")
+	}
+	for _, check := range detail.Checks {
+		result := check.Conclusion
+		if check.Status != "completed" {
+			result = check.Status
+		}
+		status := formatter.CheckStatus(models.AggregateCheckStatus([]models.CheckRun{check}).Status)
+		b.WriteString(fmt.Sprintf("  %s %s %s\n", status, formatter.PadToWidth(check.Name, 40), result))
+	}
+
+	writeDetailSection(b, fmt.Sprintf("Files (%d)", detail.ChangedFiles))
+	for _, file := range detail.Files {
+		b.WriteString(fmt.Sprintf("  %s %s  %s\n",
+			successStyle.Render(fmt.Sprintf("%6s", fmt.Sprintf("+%d", file.Additions))),
+			errorStyle.Render(fmt.Sprintf("%-6s", fmt.Sprintf("-%d", file.Deletions))),
+			file.Path))
+	}
+	if len(detail.Files) < detail.ChangedFiles {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  ... and %d more files", detail.ChangedFiles-len(detail.Files))) + "\n")
+	}
+
+	writeDetailSection(b, fmt.Sprintf("Reviews (%d)", len(detail.Reviews)))
+	if len(detail.Reviews) == 0 {
+		b.WriteString(dimStyle.Render("  No reviews") + "\n")
+	}
+	for _, review := range detail.Reviews {
+		b.WriteString(fmt.Sprintf("  %s  %s  %s\n", orDash(review.Author), review.State, formatDetailTime(review.SubmittedAt)))
+		if strings.TrimSpace(review.Body) != "" {
+			b.WriteString(renderMarkdown(review.Body, width))
+		}
+	}
+
+	title := fmt.Sprintf("Comments (%d)", detail.TotalComments)
+	if len(detail.Comments) < detail.TotalComments {
+		title = fmt.Sprintf("Comments (latest %d of %d)", len(detail.Comments), detail.TotalComments)
+	}
+	writeDetailSection(b, title)
+	if len(detail.Comments) == 0 {
+		b.WriteString(dimStyle.Render("  No comments") + "\n")
+	}
+	for _, comment := range detail.Comments {
+		b.WriteString(fmt.Sprintf("  %s  %s\n", orDash(comment.Author), formatDetailTime(comment.CreatedAt)))
+		b.WriteString(renderMarkdown(comment.Body, width))
+	}
+}
+
+// writeDetailSection writes a section heading of the detail view
+func writeDetailSection(b *strings.Builder, title string) {
+	b.WriteString("\n" + selectedStyle.Render("── "+title+" ──") + "\n")
+}
+
+// formatDetailTime formats a timestamp with its age
+func formatDetailTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Local().Format("2006-01-02 15:04"), formatter.FormatDuration(time.Since(t)))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// HTML cleanup for Markdown written by bots.
+// Renovate and Dependabot wrap release notes in <details> blocks, add HTML
+// comments with metadata, and show merge confidence as badge images.
+var (
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	summaryPattern     = regexp.MustCompile(`(?is)<summary>(.*?)</summary>`)
+	lineBreakPattern   = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagPattern     = regexp.MustCompile(`(?i)</?(?:a|b|blockquote|code|details|div|em|h[1-6]|i|img|kbd|p|pre|span|strong|sub|sup)(?:\s[^>]*)?/?>`)
+	imagePattern       = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+)
+
+// cleanMarkdown removes HTML that terminals cannot show and replaces badge images with their alt text
+func cleanMarkdown(body string) string {
+	body = htmlCommentPattern.ReplaceAllString(body, "")
+	body = summaryPattern.ReplaceAllString(body, "\n**$1**\n")
+	body = lineBreakPattern.ReplaceAllString(body, "\n")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	return imagePattern.ReplaceAllString(body, "$1")
+}
+
+// renderMarkdown renders Markdown for the terminal, falling back to the plain text
+func renderMarkdown(body string, width int) string {
+	body = cleanMarkdown(body)
+	if strings.TrimSpace(body) == "" {
+		return dimStyle.Render("  No description") + "\n"
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(markdownStyle),
+		glamour.WithWordWrap(width),
+	)
+	if err == nil {
+		if rendered, err := renderer.Render(body); err == nil {
+			return rendered
+		}
+	}
+	return body + "\n"
+}
@@ -0,0 +1,325 @@
+package interactive
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// lockfileNames lists dependency lock files, whose diffs are collapsed by default
+var lockfileNames = map[string]bool{
+	".terraform.lock.hcl": true,
+	"bun.lock":            true,
+	"Cargo.lock":          true,
+	"composer.lock":       true,
+	"deno.lock":           true,
+	"flake.lock":          true,
+	"Gemfile.lock":        true,
+	"go.sum":              true,
+	"gradle.lockfile":     true,
+	"mix.lock":            true,
+	"npm-shrinkwrap.json": true,
+	"package-lock.json":   true,
+	"Package.resolved":    true,
+	"packages.lock.json":  true,
+	"Pipfile.lock":        true,
+	"pnpm-lock.yaml":      true,
+	"Podfile.lock":        true,
+	"poetry.lock":         true,
+	"pubspec.lock":        true,
+	"uv.lock":             true,
+	"yarn.lock":           true,
+}
+
+// isLockfile reports whether the file is a dependency lock file
+func isLockfile(filePath string) bool {
+	return lockfileNames[path.Base(filePath)]
+}
+
+// diffFile is the diff of a single file
+type diffFile struct {
+	path      string
+	lines     []string // Diff lines after the "diff --git" header
+	additions int
+	deletions int
+	collapsed bool // Whether the lines are hidden
+}
+
+// parseDiff splits a unified diff into files.
+// Lock files start collapsed.
+func parseDiff(text string) []diffFile {
+	var files []diffFile
+	var current *diffFile
+	inHunk := false
+
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			filePath := line
+			if i := strings.LastIndex(line, " b/"); i >= 0 {
+				filePath = line[i+3:]
+			}
+			files = append(files, diffFile{path: filePath, collapsed: isLockfile(filePath)})
+			current = &files[len(files)-1]
+			inHunk = false
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		current.lines = append(current.lines, line)
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+			// File header (index, mode, ---/+++ lines)
+		case strings.HasPrefix(line, "+"):
+			current.additions++
+		case strings.HasPrefix(line, "-"):
+			current.deletions++
+		}
+	}
+	return files
+}
+
+// diffState holds the diff view of a single PR
+type diffState struct {
+	pager
+	pr         models.PullRequest
+	files      []diffFile
+	fileStarts []int // Line index of each file header
+	file       int   // Index of the current file
+	err        error // Error of the last fetch
+	loading    bool  // Whether the diff is being fetched
+}
+
+// diffLoadedMsg represents the result of fetching the diff of a PR
+type diffLoadedMsg struct {
+	id   PRIdentifier
+	diff string
+	err  error
+}
+
+// openDiff shows the diff view of a PR and fetches its diff
+func (m *model) openDiff(pr models.PullRequest) tea.Cmd {
+	m.diff = &diffState{pr: pr}
+	return m.loadDiff()
+}
+
+// loadDiff fetches the diff of the PR in the diff view
+func (m *model) loadDiff() tea.Cmd {
+	state := m.diff
+	state.loading = true
+	state.err = nil
+	m.renderDiff()
+
+	pr := state.pr
+	return func() tea.Msg {
+		owner, repo, err := api.ParseRepository(pr.Repository)
+		if err != nil {
+			return diffLoadedMsg{id: identify(pr), err: err}
+		}
+		diff, err := m.client.FetchPullRequestDiff(m.ctx, owner, repo, pr.Number)
+		return diffLoadedMsg{id: identify(pr), diff: diff, err: err}
+	}
+}
+
+// handleDiffLoaded stores a fetched diff if the diff view still shows the PR
+func (m model) handleDiffLoaded(msg diffLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.diff == nil || identify(m.diff.pr) != msg.id {
+		return m, nil
+	}
+	m.diff.loading = false
+	m.diff.err = msg.err
+	if msg.err == nil {
+		m.diff.files = parseDiff(msg.diff)
+		m.diff.file = 0
+		m.diff.offset = 0
+	}
+	m.renderDiff()
+	return m, nil
+}
+
+// updateDiffView handles key presses in the diff view
+func (m model) updateDiffView(key string) (tea.Model, tea.Cmd) {
+	state := m.diff
+	page := m.pagerPageSize()
+	action := m.keys.action(modeDiff, key)
+	offset := state.offset
+	if state.handleAction(action, page) {
+		m.followDiffScroll(offset)
+		return m, nil
+	}
+
+	switch action {
+	case "quit":
+		m.done = true
+		return m, tea.Quit
+
+	case "help":
+		m.openHelp()
+
+	case "back":
+		m.diff = nil
+
+	case "next_file":
+		if state.file+1 < len(state.files) {
+			m.jumpToFile(state.file + 1)
+		}
+
+	case "prev_file":
+		if state.file > 0 {
+			m.jumpToFile(state.file - 1)
+		}
+
+	case "toggle_file":
+		// Expand or collapse the current file, keeping its header in view
+		if state.file < len(state.files) {
+			state.files[state.file].collapsed = !state.files[state.file].collapsed
+			m.jumpToFile(state.file)
+		}
+
+	case "open":
+		if err := openBrowser(state.pr.URL + "/files"); err != nil {
+			m.message = fmt.Sprintf("Failed to open browser: %v", err)
+			m.messageType = "error"
+		}
+
+	case "refresh":
+		if !state.loading {
+			return m, m.loadDiff()
+		}
+	}
+
+	return m, nil
+}
+
+// followDiffScroll makes the file at the scroll position current after the diff was scrolled from offset
+func (m *model) followDiffScroll(offset int) {
+	state := m.diff
+	if file := state.fileAtOffset(); state.offset != offset && file != state.file {
+		state.file = file
+		m.renderDiff()
+	}
+}
+
+// jumpToFile makes a file the current one and scrolls to its header
+func (m *model) jumpToFile(file int) {
+	state := m.diff
+	state.file = file
+	m.renderDiff()
+	state.offset = state.fileStarts[file]
+	state.scroll(0, m.pagerPageSize())
+}
+
+// fileAtOffset returns the index of the last file whose header is at or above the top of the view
+func (s *diffState) fileAtOffset() int {
+	file := 0
+	for i, start := range s.fileStarts {
+		if start > s.offset {
+			break
+		}
+		file = i
+	}
+	return file
+}
+
+// renderDiff renders the content of the diff view for the current width
+func (m *model) renderDiff() {
+	state := m.diff
+	pr := state.pr
+	width := m.width - 2
+	if width < 40 {
+		width = 40
+	}
+
+	state.lines = []string{headerStyle.Render(fmt.Sprintf("%s#%d", pr.Repository, pr.Number)) + " " + pr.Title}
+	state.fileStarts = nil
+
+	switch {
+	case state.loading:
+		state.lines = append(state.lines, "", dimStyle.Render("  "+formatter.Symbols().Running+" Loading diff..."))
+	case state.err != nil:
+		state.lines = append(state.lines, "", errorStyle.Render(fmt.Sprintf("  %s Failed to load diff: %v", formatter.Symbols().Failed, state.err)))
+	case len(state.files) == 0:
+		state.lines = append(state.lines, "", dimStyle.Render("  No changes"))
+	}
+
+	for i, file := range state.files {
+		state.fileStarts = append(state.fileStarts, len(state.lines))
+
+		marker := "  "
+		if i == state.file {
+			marker = icon.cursor + " "
+		}
+		header := fmt.Sprintf("%s%s  +%d -%d", marker, file.path, file.additions, file.deletions)
+		if file.collapsed {
+			header += fmt.Sprintf("  (collapsed, %s to expand)", m.keys.hint(modeDiff, "toggle_file"))
+		}
+		state.lines = append(state.lines, diffFileStyle.Render(formatter.TruncateToWidth(header, width)))
+
+		if file.collapsed {
+			state.lines = append(state.lines, dimStyle.Render(fmt.Sprintf("  ⋯ %d lines hidden", len(file.lines))), "")
+			continue
+		}
+
+		// Code is highlighted by the language of the file, and the prefix
+		// column keeps the added and deleted colors
+		highlighter := newCodeHighlighter(file.path)
+		inHunk := false
+		for _, line := range file.lines {
+			text := formatter.TruncateToWidth(strings.ReplaceAll(line, "\t", "    "), width)
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				inHunk = true
+				state.lines = append(state.lines, diffHunkStyle.Render(text))
+			case !inHunk:
+				state.lines = append(state.lines, dimStyle.Render(text))
+			case highlighter != nil && text != "" && strings.ContainsAny(text[:1], "+- "):
+				prefix := normalStyle
+				switch text[0] {
+				case '+':
+					prefix = diffAddStyle
+				case '-':
+					prefix = diffDeleteStyle
+				}
+				state.lines = append(state.lines, prefix.Render(text[:1])+highlighter.highlight(text[1:]))
+			case strings.HasPrefix(line, "+"):
+				state.lines = append(state.lines, diffAddStyle.Render(text))
+			case strings.HasPrefix(line, "-"):
+				state.lines = append(state.lines, diffDeleteStyle.Render(text))
+			default:
+				state.lines = append(state.lines, text)
+			}
+		}
+		state.lines = append(state.lines, "")
+	}
+
+	state.scroll(0, m.pagerPageSize())
+}
+
+// renderDiffView renders the visible part of the diff view
+func (m model) renderDiffView() string {
+	state := m.diff
+	lines, end := state.visible(m.pagerPageSize())
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+
+	footer := fmt.Sprintf("  Lines %d-%d of %d", state.offset+1, end, len(state.lines))
+	if state.file < len(state.files) {
+		footer = fmt.Sprintf("  File %d/%d: %s  |%s", state.file+1, len(state.files), state.files[state.file].path, footer)
+	}
+	footer += fmt.Sprintf("  |  %s/%s to scroll, %s/%s for next/previous file, %s to expand/collapse, %s for help, %s to go back",
+		m.keys.hint(modeDiff, "down"), m.keys.hint(modeDiff, "up"), m.keys.hint(modeDiff, "next_file"), m.keys.hint(modeDiff, "prev_file"),
+		m.keys.hint(modeDiff, "toggle_file"), m.keys.hint(modeDiff, "help"), m.keys.hint(modeDiff, "back"))
+	b.WriteString("\n" + dimStyle.Render(footer) + "\n")
+	return b.String()
+}
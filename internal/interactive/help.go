@@ -0,0 +1,66 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/formatter"
+)
+
+// helpModeTitles are the section headings of the help overlay
+var helpModeTitles = map[keyMode]string{
+	modeList:         "PR list",
+	modeSearch:       "Search",
+	modeConfirm:      "Confirmation",
+	modeDetail:       "Detail view",
+	modeDiff:         "Diff view",
+	modeRepositories: "Repositories view",
+	modeActions:      "Action log",
+}
+
+// helpKeyWidth is the width of the key column of the help overlay
+const helpKeyWidth = 22
+
+// openHelp shows the help overlay generated from the active keybindings
+func (m *model) openHelp() {
+	var lines []string
+	for _, mode := range keyModes {
+		lines = append(lines, selectedStyle.Render(fmt.Sprintf("── %s (%s) ──", helpModeTitles[mode], mode)))
+		for _, b := range defaultBindings[mode] {
+			keys := formatter.TruncateToWidth(m.keys.hints(mode, b.action), helpKeyWidth)
+			lines = append(lines, fmt.Sprintf("  %-*s %s %s", helpKeyWidth, keys, b.help, dimStyle.Render("("+b.action+")")))
+		}
+		if mode == modeSearch {
+			lines = append(lines, dimStyle.Render("  Other keys are typed into the query"))
+		}
+		lines = append(lines, "")
+	}
+	lines = append(lines, dimStyle.Render("  Keys can be changed with keymap and keybindings in the config file"))
+	m.help = &pager{lines: lines}
+}
+
+// updateHelp scrolls the help overlay with the pager keys and closes it with any other key
+func (m model) updateHelp(key string) (tea.Model, tea.Cmd) {
+	if m.help.handleAction(m.keys.action(modeDetail, key), m.pagerPageSize()) {
+		return m, nil
+	}
+	m.help = nil
+	return m, nil
+}
+
+// renderHelp renders the visible part of the help overlay
+func (m model) renderHelp() string {
+	lines, end := m.help.visible(m.pagerPageSize())
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(" Keybindings ") + "\n\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+
+	footer := fmt.Sprintf("  Lines %d-%d of %d  |  %s/%s to scroll, any other key to close",
+		m.help.offset+1, end, len(m.help.lines), m.keys.hint(modeDetail, "down"), m.keys.hint(modeDetail, "up"))
+	b.WriteString("\n" + dimStyle.Render(footer) + "\n")
+	return b.String()
+}
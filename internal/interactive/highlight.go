@@ -0,0 +1,79 @@
+package interactive
+
+import (
+	"path"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// codeHighlighter colors the code of diff lines by the language of a file
+type codeHighlighter struct {
+	lexer  chroma.Lexer
+	style  *chroma.Style
+	styles map[chroma.TokenType]lipgloss.Style // Token styles converted so far
+}
+
+// newCodeHighlighter returns a highlighter for the file, or nil if the
+// language is unknown or colors are disabled
+func newCodeHighlighter(filePath string) *codeHighlighter {
+	if syntaxStyle == "" {
+		return nil
+	}
+	lexer := lexers.Match(path.Base(filePath))
+	if lexer == nil {
+		return nil
+	}
+	return &codeHighlighter{
+		lexer:  chroma.Coalesce(lexer),
+		style:  styles.Get(syntaxStyle),
+		styles: make(map[chroma.TokenType]lipgloss.Style),
+	}
+}
+
+// highlight colors a line of code.
+// Lines are highlighted one at a time because hunks don't start at token
+// boundaries, so constructs spanning lines (e.g. block comments) may be missed.
+func (h *codeHighlighter) highlight(code string) string {
+	if h == nil {
+		return code
+	}
+	iterator, err := h.lexer.Tokenise(nil, code)
+	if err != nil {
+		return code
+	}
+
+	var b strings.Builder
+	for _, token := range iterator.Tokens() {
+		value := strings.TrimRight(token.Value, "\n")
+		if value == "" {
+			continue
+		}
+		b.WriteString(h.tokenStyle(token.Type).Render(value))
+	}
+	return b.String()
+}
+
+// tokenStyle converts the chroma style of a token type to a lipgloss style
+func (h *codeHighlighter) tokenStyle(tokenType chroma.TokenType) lipgloss.Style {
+	if style, ok := h.styles[tokenType]; ok {
+		return style
+	}
+
+	entry := h.style.Get(tokenType)
+	style := lipgloss.NewStyle()
+	if entry.Colour.IsSet() {
+		style = style.Foreground(lipgloss.Color(entry.Colour.String()))
+	}
+	if entry.Bold == chroma.Yes {
+		style = style.Bold(true)
+	}
+	if entry.Italic == chroma.Yes {
+		style = style.Italic(true)
+	}
+	h.styles[tokenType] = style
+	return style
+}
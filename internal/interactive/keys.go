@@ -0,0 +1,383 @@
+package interactive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// keyMode is an input mode of the TUI with its own keybindings
+type keyMode string
+
+const (
+	modeList         keyMode = "list"         // PR list
+	modeSearch       keyMode = "search"       // Typing a search query
+	modeConfirm      keyMode = "confirm"      // Confirmation modals (single and bulk)
+	modeDetail       keyMode = "detail"       // PR detail view
+	modeDiff         keyMode = "diff"         // PR diff view
+	modeRepositories keyMode = "repositories" // Repositories view
+	modeActions      keyMode = "actions"      // Action log
+)
+
+// keyModes lists the modes in the order the help overlay shows them
+var keyModes = []keyMode{modeList, modeSearch, modeConfirm, modeDetail, modeDiff, modeRepositories, modeActions}
+
+// binding assigns keys to an action
+type binding struct {
+	action string
+	keys   []string
+	help   string // Description shown in the help overlay
+}
+
+// pagerBindings are the scroll bindings shared by the detail and diff views
+var pagerBindings = []binding{
+	{"up", []string{"up", "k"}, "Scroll up"},
+	{"down", []string{"down", "j"}, "Scroll down"},
+	{"half_page_up", []string{"ctrl+u"}, "Scroll half a page up"},
+	{"half_page_down", []string{"ctrl+d"}, "Scroll half a page down"},
+	{"page_up", []string{"ctrl+b"}, "Scroll a page up"},
+	{"page_down", []string{"ctrl+f", "space"}, "Scroll a page down"},
+	{"top", []string{"g"}, "Go to the top"},
+	{"bottom", []string{"G"}, "Go to the bottom"},
+}
+
+// defaultBindings lists the built-in bindings of each mode, in help order
+var defaultBindings = map[keyMode][]binding{
+	modeList: {
+		{"up", []string{"up", "k"}, "Move up"},
+		{"down", []string{"down", "j"}, "Move down"},
+		{"half_page_up", []string{"ctrl+u"}, "Move half a page up"},
+		{"half_page_down", []string{"ctrl+d"}, "Move half a page down"},
+		{"page_up", []string{"ctrl+b"}, "Move a page up"},
+		{"page_down", []string{"ctrl+f"}, "Move a page down"},
+		{"top", []string{"home"}, "Go to the first PR"},
+		{"bottom", []string{"end"}, "Go to the last PR"},
+		{"scroll_left", []string{"left", "h"}, "Scroll columns left"},
+		{"scroll_right", []string{"right", "l"}, "Scroll columns right"},
+		{"search", []string{"/"}, "Search (filter expression)"},
+		{"switch_view", []string{"tab"}, "Switch to the repositories view"},
+		{"action_log", []string{"L"}, "Show the action log"},
+		{"next_tab", []string{"]"}, "Next status tab"},
+		{"prev_tab", []string{"["}, "Previous status tab"},
+		{"tree", []string{"t"}, "Group PRs by repository (tree view)"},
+		{"toggle_group", []string{"z"}, "Collapse or expand repository (tree view)"},
+		{"detail", []string{"d"}, "Show PR details"},
+		{"diff", []string{"D"}, "Show PR diff"},
+		{"open", []string{"o"}, "Open PR in browser"},
+		{"select", []string{"space"}, "Select or deselect PR"},
+		{"select_all", []string{"a"}, "Select all visible PRs"},
+		{"invert", []string{"i"}, "Invert selection of visible PRs"},
+		{"sort", []string{"s"}, "Change sort column"},
+		{"sort_direction", []string{"S"}, "Change sort direction"},
+		{"refresh", []string{"r"}, "Refresh PRs"},
+		{"rerun", []string{"R"}, "Re-run failed checks"},
+		{"merge", []string{"enter"}, "Merge or rebase PR (or act on selected PRs)"},
+		{"clear", []string{"esc"}, "Clear selection, then filter, then quit"},
+		{"help", []string{"?"}, "Show keybindings"},
+		{"quit", []string{"q"}, "Quit"},
+	},
+	modeSearch: {
+		{"up", []string{"ctrl+k"}, "Move up"},
+		{"down", []string{"ctrl+j"}, "Move down"},
+		{"delete_char", []string{"backspace"}, "Delete last character"},
+		{"merge", []string{"enter"}, "Merge or rebase PR"},
+		{"cancel", []string{"esc"}, "Clear query and leave search"},
+	},
+	modeConfirm: {
+		{"confirm", []string{"y", "enter"}, "Confirm"},
+		{"cancel", []string{"n", "esc", "q"}, "Cancel"},
+		{"next_action", []string{"tab"}, "Next bulk action"},
+		{"prev_action", []string{"shift+tab"}, "Previous bulk action"},
+	},
+	modeDetail: append(append([]binding{}, pagerBindings...), []binding{
+		{"diff", []string{"D"}, "Show PR diff"},
+		{"open", []string{"o"}, "Open PR in browser"},
+		{"refresh", []string{"r"}, "Reload details"},
+		{"back", []string{"esc", "d"}, "Back to the PR list"},
+		{"help", []string{"?"}, "Show keybindings"},
+		{"quit", []string{"q"}, "Quit"},
+	}...),
+	modeDiff: append(append([]binding{}, pagerBindings...), []binding{
+		{"next_file", []string{"n"}, "Next file"},
+		{"prev_file", []string{"p"}, "Previous file"},
+		{"toggle_file", []string{"enter", "z"}, "Expand or collapse file"},
+		{"open", []string{"o"}, "Open changed files in browser"},
+		{"refresh", []string{"r"}, "Reload diff"},
+		{"back", []string{"esc", "D"}, "Back"},
+		{"help", []string{"?"}, "Show keybindings"},
+		{"quit", []string{"q"}, "Quit"},
+	}...),
+	modeRepositories: {
+		{"up", []string{"up", "k"}, "Move up"},
+		{"down", []string{"down", "j"}, "Move down"},
+		{"show_prs", []string{"enter"}, "Show PRs of the repository"},
+		{"switch_view", []string{"tab"}, "Switch to the action log"},
+		{"back", []string{"esc"}, "Back to the PR list"},
+		{"refresh", []string{"r"}, "Refresh PRs"},
+		{"help", []string{"?"}, "Show keybindings"},
+		{"quit", []string{"q"}, "Quit"},
+	},
+	modeActions: {
+		{"up", []string{"up", "k"}, "Move up"},
+		{"down", []string{"down", "j"}, "Move down"},
+		{"undo", []string{"u"}, "Undo the action (delete comment, restore PR body or dismiss approval)"},
+		{"switch_view", []string{"tab"}, "Switch to the PR list"},
+		{"back", []string{"esc", "L"}, "Back to the PR list"},
+		{"help", []string{"?"}, "Show keybindings"},
+		{"quit", []string{"q"}, "Quit"},
+	},
+}
+
+// keymapPresets add alternative keys to the built-in bindings.
+// Names use the same format as the keybindings in the config file.
+var keymapPresets = map[string]map[string][]string{
+	"default": nil,
+	"vim": {
+		"list.top":       {"g"},
+		"list.bottom":    {"G"},
+		"search.up":      {"ctrl+p"},
+		"search.down":    {"ctrl+n"},
+		"detail.up":      {"ctrl+y"},
+		"detail.down":    {"ctrl+e"},
+		"diff.up":        {"ctrl+y"},
+		"diff.down":      {"ctrl+e"},
+		"diff.next_file": {"]"},
+		"diff.prev_file": {"["},
+	},
+	"emacs": {
+		"up":               {"ctrl+p"},
+		"down":             {"ctrl+n"},
+		"page_up":          {"alt+v"},
+		"page_down":        {"ctrl+v"},
+		"top":              {"alt+<"},
+		"bottom":           {"alt+>"},
+		"back":             {"ctrl+g"},
+		"list.search":      {"ctrl+s"},
+		"list.clear":       {"ctrl+g"},
+		"search.up":        {"ctrl+p"},
+		"search.down":      {"ctrl+n"},
+		"search.cancel":    {"ctrl+g"},
+		"confirm.cancel":   {"ctrl+g"},
+		"diff.next_file":   {"alt+n"},
+		"diff.prev_file":   {"alt+p"},
+		"diff.toggle_file": {"tab"},
+	},
+}
+
+// ValidateKeymap returns an error if name is not a keymap preset ("" = default)
+func ValidateKeymap(name string) error {
+	if _, ok := keymapPresets[name]; ok || name == "" {
+		return nil
+	}
+	names := make([]string, 0, len(keymapPresets))
+	for preset := range keymapPresets {
+		names = append(names, preset)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown keymap: %s (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+// ValidateKeybindings returns an error if bindings refer to an unknown action.
+// A binding name is either "mode.action" or a bare action, which applies to
+// every mode that has the action except search.
+func ValidateKeybindings(bindings map[string][]string) error {
+	for name := range bindings {
+		if len(bindingModes(name)) == 0 {
+			return fmt.Errorf("unknown keybinding action: %s (expected an action such as %s, optionally prefixed with a mode: %s)",
+				name, strings.Join(actionNames(), ", "), formatModes())
+		}
+	}
+	return nil
+}
+
+// actionNames returns the names of all actions
+func actionNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, mode := range keyModes {
+		for _, b := range defaultBindings[mode] {
+			if !seen[b.action] {
+				seen[b.action] = true
+				names = append(names, b.action)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatModes formats the mode names for messages
+func formatModes() string {
+	names := make([]string, len(keyModes))
+	for i, mode := range keyModes {
+		names[i] = string(mode)
+	}
+	return strings.Join(names, ", ")
+}
+
+// bindingModes returns the modes a keybinding name applies to
+func bindingModes(name string) []keyMode {
+	if mode, action, ok := strings.Cut(name, "."); ok {
+		if hasAction(keyMode(mode), action) {
+			return []keyMode{keyMode(mode)}
+		}
+		return nil
+	}
+
+	// Search keys are typed into the query unless the mode is given explicitly
+	var modes []keyMode
+	for _, mode := range keyModes {
+		if mode != modeSearch && hasAction(mode, name) {
+			modes = append(modes, mode)
+		}
+	}
+	return modes
+}
+
+// hasAction reports whether the mode has a built-in binding for the action
+func hasAction(mode keyMode, action string) bool {
+	for _, b := range defaultBindings[mode] {
+		if b.action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// keyMap resolves pressed keys to actions, per mode
+type keyMap struct {
+	actions map[keyMode]map[string]string   // Key to action
+	keys    map[keyMode]map[string][]string // Action to keys, in binding order
+}
+
+// newKeyMap builds the active keybindings from the built-in bindings, the
+// keymap preset and the configured bindings (later ones win on conflicts)
+func newKeyMap(preset string, bindings map[string][]string) keyMap {
+	k := keyMap{
+		actions: make(map[keyMode]map[string]string),
+		keys:    make(map[keyMode]map[string][]string),
+	}
+	for _, mode := range keyModes {
+		k.actions[mode] = make(map[string]string)
+		k.keys[mode] = make(map[string][]string)
+		for _, b := range defaultBindings[mode] {
+			k.bind(mode, b.action, b.keys)
+		}
+	}
+
+	for _, extra := range []map[string][]string{keymapPresets[preset], bindings} {
+		// Bare actions first, so that mode-specific bindings win
+		names := make([]string, 0, len(extra))
+		for name := range extra {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			iScoped, jScoped := strings.Contains(names[i], "."), strings.Contains(names[j], ".")
+			if iScoped != jScoped {
+				return jScoped
+			}
+			return names[i] < names[j]
+		})
+
+		for _, name := range names {
+			action := name
+			if _, scoped, ok := strings.Cut(name, "."); ok {
+				action = scoped
+			}
+			for _, mode := range bindingModes(name) {
+				k.bind(mode, action, extra[name])
+			}
+		}
+	}
+	return k
+}
+
+// bind assigns keys to an action, taking them away from other actions of the mode
+func (k keyMap) bind(mode keyMode, action string, keys []string) {
+	for _, key := range keys {
+		previous, ok := k.actions[mode][key]
+		if ok && previous == action {
+			continue
+		}
+		if ok {
+			k.keys[mode][previous] = removeKey(k.keys[mode][previous], key)
+		}
+		k.actions[mode][key] = action
+		k.keys[mode][action] = append(k.keys[mode][action], key)
+	}
+}
+
+// action returns the action bound to the key in the mode ("" = none)
+func (k keyMap) action(mode keyMode, key string) string {
+	return k.actions[mode][key]
+}
+
+// hint formats the first key of an action for inline help texts (e.g. "Ctrl+U")
+func (k keyMap) hint(mode keyMode, action string) string {
+	keys := k.keys[mode][action]
+	if len(keys) == 0 {
+		return "(unbound)"
+	}
+	return formatKey(keys[0])
+}
+
+// hints formats all keys of an action for the help overlay (e.g. "↑, k")
+func (k keyMap) hints(mode keyMode, action string) string {
+	keys := k.keys[mode][action]
+	if len(keys) == 0 {
+		return "(unbound)"
+	}
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = formatKey(key)
+	}
+	return strings.Join(names, ", ")
+}
+
+// removeKey returns keys without key
+func removeKey(keys []string, key string) []string {
+	var rest []string
+	for _, k := range keys {
+		if k != key {
+			rest = append(rest, k)
+		}
+	}
+	return rest
+}
+
+// keyNames are the display names of special keys
+var keyNames = map[string]string{
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+	"enter":     "Enter",
+	"esc":       "Esc",
+	"tab":       "Tab",
+	"space":     "Space",
+	"backspace": "Backspace",
+	"home":      "Home",
+	"end":       "End",
+}
+
+// formatKey formats a key for display (e.g. "ctrl+u" as "Ctrl+U")
+func formatKey(key string) string {
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+	parts := strings.Split(key, "+")
+	if len(parts) == 1 || key == "+" {
+		return key
+	}
+	for i, part := range parts[:len(parts)-1] {
+		parts[i] = capitalize(part)
+	}
+	last := parts[len(parts)-1]
+	if name, ok := keyNames[last]; ok {
+		last = name
+	} else {
+		last = strings.ToUpper(last)
+	}
+	parts[len(parts)-1] = last
+	return strings.Join(parts, "+")
+}
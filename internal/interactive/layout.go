@@ -0,0 +1,218 @@
+package interactive
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/sorter"
+)
+
+// listColumn is a column of the PR list
+type listColumn struct {
+	header string
+	min    int    // Narrowest width when shrinking to fit the terminal
+	max    int    // Widest width (0 = takes the remaining width)
+	sort   string // Sort field selected by clicking the header ("" = not sortable)
+}
+
+// listColumns are the columns of the PR list, in display order
+var listColumns = []listColumn{
+	{header: "#", min: 6, max: 6},
+	{header: "REPO", min: 8, max: 20, sort: sorter.FieldRepo},
+	{header: "BOT", min: 6, max: 12, sort: sorter.FieldBot},
+	{header: "CI", min: 4, max: 4, sort: sorter.FieldCI},
+	{header: "MERGE", min: 5, max: 6, sort: sorter.FieldMergeable},
+	{header: "LABELS", min: 6, max: 15},
+	{header: "VERSION", min: 8, max: 12},
+	{header: "TITLE", min: 20},
+}
+
+// Layout constants
+const (
+	listPrefixWidth    = 2 // Cursor and selection mark before each row
+	horizontalScrollBy = 8 // Columns scrolled per key press
+	mouseWheelScrollBy = 3 // Lines scrolled per wheel step in the pagers
+)
+
+// columnWidths fits the columns into the terminal width.
+// Columns start at their max width and the title takes the remaining width.
+// If the title would be narrower than its min width, the other columns are
+// narrowed, widest first, down to their min width; anything still too wide
+// is reached by scrolling horizontally.
+func (m model) columnWidths() []int {
+	widths := make([]int, len(listColumns))
+	total := len(listColumns) - 1 // Separators
+	flexible := -1
+	for i, column := range listColumns {
+		if column.max == 0 {
+			widths[i] = column.min
+			flexible = i
+		} else {
+			widths[i] = column.max
+		}
+		total += widths[i]
+	}
+
+	available := m.width - listPrefixWidth
+	for total > available {
+		widest := -1
+		for i, column := range listColumns {
+			if widths[i] > column.min && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	if flexible >= 0 && total < available {
+		widths[flexible] += available - total
+	}
+	return widths
+}
+
+// lineWidth returns the display width of a row without the prefix
+func lineWidth(widths []int) int {
+	total := len(widths) - 1
+	for _, w := range widths {
+		total += w
+	}
+	return total
+}
+
+// formatColumns lays out cell values in the list columns and cuts the
+// visible part for the horizontal scroll position
+func (m model) formatColumns(values []string) string {
+	widths := m.columnWidths()
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = formatter.PadToWidth(value, widths[i])
+	}
+	return formatter.CutToWidth(strings.Join(cells, " "), m.xOffset, m.width-listPrefixWidth)
+}
+
+// formatListHeader formats the column headers of the PR list
+func (m model) formatListHeader() string {
+	headers := make([]string, len(listColumns))
+	for i, column := range listColumns {
+		headers[i] = column.header
+	}
+	return strings.Repeat(" ", listPrefixWidth) + m.formatColumns(headers)
+}
+
+// scrollHorizontally moves the visible part of the rows by delta columns
+func (m *model) scrollHorizontally(delta int) {
+	maxOffset := lineWidth(m.columnWidths()) - (m.width - listPrefixWidth)
+	m.xOffset += delta
+	if m.xOffset > maxOffset {
+		m.xOffset = maxOffset
+	}
+	if m.xOffset < 0 {
+		m.xOffset = 0
+	}
+}
+
+// columnAt returns the list column at a screen column (false between columns)
+func (m model) columnAt(x int) (int, bool) {
+	x = x - listPrefixWidth + m.xOffset
+	start := 0
+	for i, w := range m.columnWidths() {
+		if x >= start && x < start+w {
+			return i, true
+		}
+		start += w + 1
+	}
+	return 0, false
+}
+
+// visibleRows returns the range of rows shown around the cursor
+func (m model) visibleRows() (start, end int) {
+	maxVisible := m.getPageSize()
+
+	start = m.cursor - maxVisible/2
+	if start < 0 {
+		start = 0
+	}
+	end = start + maxVisible
+	if end > len(m.rows) {
+		end = len(m.rows)
+		start = end - maxVisible
+		if start < 0 {
+			start = 0
+		}
+	}
+	return start, end
+}
+
+// handleMouseClick moves the cursor to a clicked row, expands or collapses a
+// clicked repository header and sorts by a clicked column header
+func (m model) handleMouseClick(mouse tea.Mouse) (tea.Model, tea.Cmd) {
+	if mouse.Button != tea.MouseLeft || !m.listShown() {
+		return m, nil
+	}
+
+	// The column headers and a separator are the last lines above the rows
+	top := strings.Count(m.renderListTop(), "\n")
+	switch {
+	case mouse.Y == top-2:
+		if i, ok := m.columnAt(mouse.X); ok && listColumns[i].sort != "" {
+			m.sortBy(listColumns[i].sort)
+		}
+	case mouse.Y >= top:
+		start, end := m.visibleRows()
+		if row := start + mouse.Y - top; row < end {
+			m.cursor = row
+			if m.rows[row].header {
+				m.toggleGroup()
+			}
+		}
+	}
+	return m, nil
+}
+
+// handleMouseWheel scrolls the list or the pager under the wheel
+func (m model) handleMouseWheel(mouse tea.Mouse) (tea.Model, tea.Cmd) {
+	delta := 0
+	switch mouse.Button {
+	case tea.MouseWheelUp:
+		delta = -1
+	case tea.MouseWheelDown:
+		delta = 1
+	case tea.MouseWheelLeft:
+		m.scrollHorizontally(-horizontalScrollBy)
+		return m, nil
+	case tea.MouseWheelRight:
+		m.scrollHorizontally(horizontalScrollBy)
+		return m, nil
+	default:
+		return m, nil
+	}
+
+	switch {
+	case m.help != nil:
+		m.help.scroll(delta*mouseWheelScrollBy, m.pagerPageSize())
+	case m.diff != nil:
+		offset := m.diff.offset
+		m.diff.scroll(delta*mouseWheelScrollBy, m.pagerPageSize())
+		m.followDiffScroll(offset)
+	case m.detail != nil:
+		m.detail.scroll(delta*mouseWheelScrollBy, m.pagerPageSize())
+	case m.view == viewRepositories:
+		m.moveRepoCursor(delta)
+	case m.view == viewActions:
+		m.moveActionCursor(delta)
+	case m.listShown():
+		m.moveCursor(delta)
+	}
+	return m, nil
+}
+
+// listShown reports whether the PR list takes mouse input (no view or modal on top)
+func (m model) listShown() bool {
+	return m.help == nil && m.diff == nil && m.detail == nil && m.view == viewPullRequests &&
+		!m.confirmMode && m.bulk == nil
+}
@@ -0,0 +1,274 @@
+package interactive
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/audit"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// operation is an asynchronous action on a single PR
+type operation int
+
+const (
+	opMerge operation = iota
+	opRebase
+	opApprove
+	opComment
+	opRerun
+)
+
+func (o operation) String() string {
+	switch o {
+	case opRebase:
+		return "rebase"
+	case opApprove:
+		return "approve"
+	case opComment:
+		return "comment"
+	case opRerun:
+		return "rerun"
+	default:
+		return "merge"
+	}
+}
+
+// progress describes the operation while it runs (e.g. "merging")
+func (o operation) progress() string {
+	switch o {
+	case opRebase:
+		return "rebasing"
+	case opApprove:
+		return "approving"
+	case opComment:
+		return "commenting"
+	case opRerun:
+		return "re-running checks"
+	default:
+		return "merging"
+	}
+}
+
+// pollBackoff returns the delay before polling the repository after the operation succeeded
+func (o operation) pollBackoff() time.Duration {
+	switch o {
+	case opRebase, opRerun:
+		// CI takes longer to restart after a rebase or re-run
+		return pollRebaseInitialBackoff
+	default:
+		return pollInitialBackoff
+	}
+}
+
+// operationResultMsg represents the result of an operation on a single PR
+type operationResultMsg struct {
+	id      PRIdentifier // PR the operation targeted
+	pr      models.PullRequest
+	op      operation
+	comment string
+	success bool
+	message string
+	undo    *undoAction // How to revert the operation (nil = cannot be undone)
+}
+
+// startOperation marks a PR as busy and runs the operation in the background.
+// The result is applied to the targeted PR, wherever the cursor is by then.
+func (m *model) startOperation(pr models.PullRequest, op operation, comment string) tea.Cmd {
+	id := identify(pr)
+	if current, busy := m.operations[id]; busy {
+		m.message = fmt.Sprintf("PR #%d in %s is already %s", pr.Number, pr.Repository, current.progress())
+		m.messageType = "error"
+		return nil
+	}
+
+	m.operations[id] = op
+	m.message = fmt.Sprintf("%s PR #%d in %s...", capitalize(op.progress()), pr.Number, pr.Repository)
+	m.messageType = ""
+
+	return func() tea.Msg {
+		success, message, undo := m.executeOperation(pr, op, comment)
+		return operationResultMsg{id: id, pr: pr, op: op, comment: comment, success: success, message: message, undo: undo}
+	}
+}
+
+// executeOperation runs an operation on a PR and returns whether it succeeded
+// with a status message and, if the operation can be reverted, how to undo it
+func (m *model) executeOperation(pr models.PullRequest, op operation, comment string) (bool, string, *undoAction) {
+	switch op {
+	case opRebase:
+		if !pr.BotType.SupportsRebase() {
+			return false, fmt.Sprintf("Bot %s does not support rebase", pr.BotType.DisplayName()), nil
+		}
+		return m.executeRebase(pr)
+	case opApprove:
+		return m.executeApprove(pr)
+	case opComment:
+		return m.executeComment(pr, comment)
+	case opRerun:
+		success, message := m.executeRerun(pr)
+		return success, message, nil
+	default:
+		success, message := m.executeMerge(pr)
+		return success, message, nil
+	}
+}
+
+// handleOperationResult applies the result of an operation to the PR it targeted
+func (m model) handleOperationResult(msg operationResultMsg) (tea.Model, tea.Cmd) {
+	delete(m.operations, msg.id)
+	m.recordAction(audit.Entry{
+		Repository: msg.pr.Repository, Number: msg.pr.Number, Title: msg.pr.Title,
+		Action: msg.op.String(), Success: msg.success, Message: msg.message, Comment: msg.comment,
+	}, msg.undo)
+	m.message = msg.message
+	if !msg.success {
+		m.messageType = "error"
+		return m, nil
+	}
+	m.messageType = "success"
+
+	var cmds []tea.Cmd
+	if msg.op == opMerge {
+		m.removePR(msg.id)
+
+		// Pick up other changes caused by the merge (e.g. new conflicts)
+		if !m.refreshing {
+			m.refreshing = true
+			cmds = append(cmds, m.refreshPRs())
+		}
+	}
+
+	// Poll the repository to pick up the new state
+	if _, isPolling := m.pollingRepos[msg.id.Repository]; !isPolling {
+		cmds = append(cmds, m.startPolling(msg.id.Repository, msg.op.pollBackoff()))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// executeMerge merges a PR and returns whether it succeeded with a status message
+func (m *model) executeMerge(pr models.PullRequest) (bool, string) {
+	// Check for conflicts
+	if pr.MergeableState == models.MergeableStateConflicting {
+		return false, fmt.Sprintf("PR #%d has conflicts and cannot be merged", pr.Number)
+	}
+
+	// Parse repository
+	owner, repo, err := api.ParseRepository(pr.Repository)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid repository format: %v", err)
+	}
+
+	// Execute merge
+	resp, err := m.client.MergePullRequest(m.ctx, owner, repo, pr.Number, m.mergeMethod, "")
+	if err != nil {
+		return false, fmt.Sprintf("Merge failed: %v", err)
+	}
+
+	if !resp.Merged {
+		return false, fmt.Sprintf("Merge unsuccessful: %s", resp.Message)
+	}
+
+	return true, fmt.Sprintf("Successfully merged PR #%d in %s", pr.Number, pr.Repository)
+}
+
+// executeRebase asks the bot to rebase a PR and returns whether it succeeded with a status message.
+// The rebase can be undone by restoring the PR body or deleting the rebase comment.
+func (m *model) executeRebase(pr models.PullRequest) (bool, string, *undoAction) {
+	// Parse repository
+	owner, repo, err := api.ParseRepository(pr.Repository)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid repository format: %v", err), nil
+	}
+
+	// Handle based on bot type
+	if pr.BotType.UsesCheckboxRebase() {
+		// Renovate: Update PR body to check the rebase checkbox
+		if err := m.client.TriggerRenovateRebase(m.ctx, owner, repo, pr.Number, pr.Body); err != nil {
+			return false, fmt.Sprintf("Failed to trigger rebase: %v", err), nil
+		}
+		return true, fmt.Sprintf("Rebase triggered for PR #%d in %s (checkbox checked)", pr.Number, pr.Repository),
+			&undoAction{kind: undoRestoreBody, body: pr.Body}
+	} else if pr.BotType.RebaseCommand() != "" {
+		// Dependabot: Post a comment
+		comment, err := m.client.CreateComment(m.ctx, owner, repo, pr.Number, pr.BotType.RebaseCommand())
+		if err != nil {
+			return false, fmt.Sprintf("Failed to post rebase comment: %v", err), nil
+		}
+		return true, fmt.Sprintf("Rebase triggered for PR #%d in %s (comment posted)", pr.Number, pr.Repository),
+			&undoAction{kind: undoDeleteComment, commentID: comment.ID}
+	}
+
+	return false, fmt.Sprintf("Bot %s does not support rebase", pr.BotType.DisplayName()), nil
+}
+
+// executeRerun re-runs the failed checks of a PR and returns whether it succeeded with a status message
+func (m *model) executeRerun(pr models.PullRequest) (bool, string) {
+	// Parse repository
+	owner, repo, err := api.ParseRepository(pr.Repository)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid repository format: %v", err)
+	}
+
+	result, err := m.client.RerunFailedChecks(m.ctx, owner, repo, pr.HeadSHA)
+	if err != nil {
+		return false, fmt.Sprintf("Failed to re-run checks: %v", err)
+	}
+
+	if result.Count() == 0 {
+		return false, fmt.Sprintf("No failed workflow runs or check suites found for PR #%d", pr.Number)
+	}
+
+	return true, fmt.Sprintf("Re-running %d failed check(s) for PR #%d in %s", result.Count(), pr.Number, pr.Repository)
+}
+
+// executeApprove approves a PR and returns whether it succeeded with a status message.
+// The approval can be undone by dismissing the review.
+func (m *model) executeApprove(pr models.PullRequest) (bool, string, *undoAction) {
+	owner, repo, err := api.ParseRepository(pr.Repository)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid repository format: %v", err), nil
+	}
+
+	review, err := m.client.ApprovePullRequest(m.ctx, owner, repo, pr.Number)
+	if err != nil {
+		return false, fmt.Sprintf("Approval failed: %v", err), nil
+	}
+	return true, fmt.Sprintf("Approved PR #%d in %s", pr.Number, pr.Repository),
+		&undoAction{kind: undoDismissReview, reviewID: review.ID}
+}
+
+// executeComment posts a comment on a PR and returns whether it succeeded with a status message.
+// The comment can be undone by deleting it.
+func (m *model) executeComment(pr models.PullRequest, body string) (bool, string, *undoAction) {
+	owner, repo, err := api.ParseRepository(pr.Repository)
+	if err != nil {
+		return false, fmt.Sprintf("Invalid repository format: %v", err), nil
+	}
+
+	comment, err := m.client.CreateComment(m.ctx, owner, repo, pr.Number, body)
+	if err != nil {
+		return false, fmt.Sprintf("Failed to post comment: %v", err), nil
+	}
+	return true, fmt.Sprintf("Commented on PR #%d in %s", pr.Number, pr.Repository),
+		&undoAction{kind: undoDeleteComment, commentID: comment.ID}
+}
+
+// removePR removes a merged PR from the list, keeping the cursor on the selected PR
+func (m *model) removePR(id PRIdentifier) {
+	prevSelection := m.captureCurrentSelection()
+
+	for i, pr := range m.prs {
+		if identify(pr) == id {
+			m.prs = append(m.prs[:i:i], m.prs[i+1:]...)
+			break
+		}
+	}
+	delete(m.selected, id)
+
+	m.filterPRs()
+	m.restoreCursorPosition(prevSelection)
+}
@@ -0,0 +1,52 @@
+package interactive
+
+// pager is a scrollable list of rendered lines
+type pager struct {
+	lines  []string // Rendered content
+	offset int      // First visible line
+}
+
+// scroll moves the visible window by delta lines, keeping it within the content
+func (p *pager) scroll(delta, page int) {
+	p.offset += delta
+	if maxOffset := len(p.lines) - page; p.offset > maxOffset {
+		p.offset = maxOffset
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+}
+
+// handleAction scrolls for the common pager actions and reports whether the action was handled
+func (p *pager) handleAction(action string, page int) bool {
+	switch action {
+	case "up":
+		p.scroll(-1, page)
+	case "down":
+		p.scroll(1, page)
+	case "half_page_up":
+		p.scroll(-page/2, page)
+	case "half_page_down":
+		p.scroll(page/2, page)
+	case "page_up":
+		p.scroll(-page, page)
+	case "page_down":
+		p.scroll(page, page)
+	case "top":
+		p.offset = 0
+	case "bottom":
+		p.scroll(len(p.lines), page)
+	default:
+		return false
+	}
+	return true
+}
+
+// visible returns the lines shown on the current page and the index after the last one
+func (p *pager) visible(page int) ([]string, int) {
+	end := p.offset + page
+	if end > len(p.lines) {
+		end = len(p.lines)
+	}
+	return p.lines[p.offset:end], end
+}
@@ -0,0 +1,160 @@
+package interactive
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/swfz/gh-deps/internal/formatter"
+	"github.com/swfz/gh-deps/internal/health"
+)
+
+// viewMode selects the list shown by the TUI
+type viewMode int
+
+const (
+	viewPullRequests viewMode = iota // PR list (default)
+	viewRepositories                 // Repository health ranking
+	viewActions                      // Action log of this session
+)
+
+// updateRepositoryView handles key presses in the repositories view
+func (m model) updateRepositoryView(key string) (tea.Model, tea.Cmd) {
+	repos := health.Compute(m.prs, time.Now())
+
+	switch m.keys.action(modeRepositories, key) {
+	case "quit":
+		m.done = true
+		return m, tea.Quit
+
+	case "help":
+		m.openHelp()
+
+	case "switch_view":
+		m.view = viewActions
+
+	case "back":
+		m.view = viewPullRequests
+
+	case "up":
+		m.moveRepoCursor(-1)
+
+	case "down":
+		m.moveRepoCursor(1)
+
+	case "show_prs":
+		// Show the PRs of the selected repository
+		if m.repoCursor < len(repos) {
+			m.query = "repo:re:^" + regexp.QuoteMeta(repos[m.repoCursor].Repository) + "$"
+			m.tab = tabAll
+			m.filterPRs()
+			m.cursor = 0
+			m.view = viewPullRequests
+		}
+
+	case "refresh":
+		if !m.refreshing {
+			m.refreshing = true
+			m.message = "Refreshing PRs..."
+			m.messageType = ""
+			return m, m.refreshPRs()
+		}
+	}
+
+	return m, nil
+}
+
+// moveRepoCursor moves the cursor of the repositories view by delta, keeping it within the ranking
+func (m *model) moveRepoCursor(delta int) {
+	m.repoCursor += delta
+	if count := len(health.Compute(m.prs, time.Now())); m.repoCursor >= count {
+		m.repoCursor = count - 1
+	}
+	if m.repoCursor < 0 {
+		m.repoCursor = 0
+	}
+}
+
+// renderTabs renders the view tabs with their counts
+func (m model) renderTabs() string {
+	tabs := []struct {
+		mode  viewMode
+		label string
+	}{
+		{viewPullRequests, fmt.Sprintf("PRs (%d)", len(m.prs))},
+		{viewRepositories, fmt.Sprintf("Repositories (%d)", len(health.Compute(m.prs, time.Now())))},
+		{viewActions, fmt.Sprintf("Actions (%d)", len(m.actions))},
+	}
+
+	parts := make([]string, 0, len(tabs))
+	for _, tab := range tabs {
+		if tab.mode == m.view {
+			parts = append(parts, selectedStyle.Render("["+tab.label+"]"))
+		} else {
+			parts = append(parts, dimStyle.Render(" "+tab.label+" "))
+		}
+	}
+	return strings.Join(parts, " ") + dimStyle.Render(fmt.Sprintf("  (%s to switch)", m.keys.hint(modeList, "switch_view")))
+}
+
+// renderRepositoryView renders the repository health ranking, worst first
+func (m model) renderRepositoryView() string {
+	var b strings.Builder
+	repos := health.Compute(m.prs, time.Now())
+
+	listHeader := fmt.Sprintf("%-4s %-40s %-7s %-5s %-8s %-8s %-12s %s",
+		"#", "REPOSITORY", "SCORE", "OPEN", "OLDEST", "FAILING", "CONFLICTING", "SECURITY")
+	b.WriteString(dimStyle.Render(listHeader) + "\n")
+	b.WriteString(strings.Repeat("─", m.width) + "\n")
+
+	if len(repos) == 0 {
+		b.WriteString("\n" + dimStyle.Render("  No repositories with dependency update PRs") + "\n")
+		return b.String()
+	}
+
+	cursor := m.repoCursor
+	if cursor >= len(repos) {
+		cursor = len(repos) - 1
+	}
+
+	maxVisible := m.getPageSize()
+	startIdx := cursor - maxVisible/2
+	if startIdx < 0 {
+		startIdx = 0
+	}
+	endIdx := startIdx + maxVisible
+	if endIdx > len(repos) {
+		endIdx = len(repos)
+		startIdx = endIdx - maxVisible
+		if startIdx < 0 {
+			startIdx = 0
+		}
+	}
+
+	for i := startIdx; i < endIdx; i++ {
+		h := repos[i]
+		score := fmt.Sprintf("%d", h.Score)
+		if h.NeedsAttention() {
+			score += " " + formatter.Symbols().Warning
+		}
+		line := fmt.Sprintf("%-4d %-40s %-7s %-5d %-8s %-8d %-12d %d",
+			i+1, truncate(h.Repository, 40), score, h.Open, formatter.FormatDuration(h.OldestAge),
+			h.Failing, h.Conflicting, h.Security)
+
+		switch {
+		case i == cursor:
+			b.WriteString(selectedStyle.Render(icon.cursor+" "+line) + "\n")
+		case h.NeedsAttention():
+			b.WriteString(errorStyle.Render("  "+line) + "\n")
+		default:
+			b.WriteString(normalStyle.Render("  "+line) + "\n")
+		}
+	}
+
+	footer := fmt.Sprintf("  %d/%d repositories  |  %s to show PRs of the repository, %s for help",
+		cursor+1, len(repos), m.keys.hint(modeRepositories, "show_prs"), m.keys.hint(modeRepositories, "help"))
+	b.WriteString("\n" + dimStyle.Render(footer) + "\n")
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package interactive
+
+import (
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/sorter"
+)
+
+// cycleSortField makes the next sort field the primary sort key.
+// The remaining configured keys are kept as tie-breakers.
+func (m *model) cycleSortField() {
+	current := sorter.DefaultKeys[0].Field
+	if len(m.sortKeys) > 0 {
+		current = m.sortKeys[0].Field
+	}
+
+	next := sorter.Fields[0]
+	for i, field := range sorter.Fields {
+		if field == current {
+			next = sorter.Fields[(i+1)%len(sorter.Fields)]
+			break
+		}
+	}
+
+	m.applySort(m.promoteSortField(next))
+}
+
+// sortBy makes field the primary sort key, or reverses its direction if it already is
+func (m *model) sortBy(field string) {
+	if len(m.sortKeys) > 0 && m.sortKeys[0].Field == field {
+		m.toggleSortDirection()
+		return
+	}
+	m.applySort(m.promoteSortField(field))
+}
+
+// promoteSortField returns the sort keys with field as the primary key.
+// The remaining configured keys are kept as tie-breakers.
+func (m *model) promoteSortField(field string) []sorter.Key {
+	keys := []sorter.Key{{Field: field}}
+	for i, key := range m.sortKeys {
+		if i > 0 && key.Field != field {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// toggleSortDirection reverses the direction of the primary sort key
+func (m *model) toggleSortDirection() {
+	keys := append([]sorter.Key{}, m.sortKeys...)
+	if len(keys) == 0 {
+		keys = append(keys, sorter.DefaultKeys...)
+	}
+	keys[0].Descending = !keys[0].Descending
+	m.applySort(keys)
+}
+
+// applySort re-sorts the PR list and keeps the cursor on the selected PR
+func (m *model) applySort(keys []sorter.Key) {
+	prevSelection := m.captureCurrentSelection()
+
+	m.sortKeys = keys
+	sorter.Sort(m.prs, m.sortKeys)
+	m.filterPRs()
+	m.restoreCursorPosition(prevSelection)
+}
+
+// formatSortKeys returns the sort keys for display (e.g., "ci ↑, age ↓")
+func formatSortKeys(keys []sorter.Key) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		arrow := icon.ascending
+		if key.Descending {
+			arrow = icon.descending
+		}
+		parts = append(parts, key.Field+" "+arrow)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,232 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/health"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// statusTab narrows the PR list to the PRs that need the same kind of action
+type statusTab int
+
+const (
+	tabAll         statusTab = iota // Every PR matching the search query
+	tabNeedsRebase                  // Conflicting PRs
+	tabReady                        // Mergeable PRs with passing CI
+	tabFailing                      // PRs with failing CI
+	tabPending                      // PRs with pending CI
+	tabSecurity                     // Security updates
+)
+
+// statusTabs lists the tabs in the order next_tab cycles through them
+var statusTabs = []statusTab{tabAll, tabNeedsRebase, tabReady, tabFailing, tabPending, tabSecurity}
+
+func (t statusTab) String() string {
+	switch t {
+	case tabNeedsRebase:
+		return "Needs rebase"
+	case tabReady:
+		return "Ready to merge"
+	case tabFailing:
+		return "Failing"
+	case tabPending:
+		return "Pending"
+	case tabSecurity:
+		return "Security"
+	default:
+		return "All"
+	}
+}
+
+// matches reports whether the PR is listed in the tab
+func (t statusTab) matches(pr models.PullRequest) bool {
+	switch t {
+	case tabNeedsRebase:
+		return pr.MergeableState == models.MergeableStateConflicting
+	case tabReady:
+		return pr.CheckSummary.Status == models.StatusSuccess && pr.MergeableState == models.MergeableStateMergeable
+	case tabFailing:
+		return pr.CheckSummary.Status == models.StatusFailure
+	case tabPending:
+		return pr.CheckSummary.Status == models.StatusPending
+	case tabSecurity:
+		return pr.IsSecurityUpdate()
+	default:
+		return true
+	}
+}
+
+// listRow is a row of the PR list: a PR, or a repository header in the tree view
+type listRow struct {
+	header    bool               // Whether the row is a repository header
+	pr        models.PullRequest // PR of the row (PR rows only)
+	repo      string             // Repository of the PR or header
+	count     int                // PRs under the header
+	collapsed bool               // Whether the PRs under the header are hidden
+}
+
+// id identifies the row for restoring the cursor (Number is 0 for headers)
+func (r listRow) id() PRIdentifier {
+	if r.header {
+		return PRIdentifier{Repository: r.repo}
+	}
+	return identify(r.pr)
+}
+
+// applyTab narrows the PRs matching the search query to the current tab and lays out the rows
+func (m *model) applyTab() {
+	if m.tab == tabAll {
+		m.filtered = m.matched
+	} else {
+		m.filtered = nil
+		for _, pr := range m.matched {
+			if m.tab.matches(pr) {
+				m.filtered = append(m.filtered, pr)
+			}
+		}
+	}
+	m.buildRows()
+}
+
+// switchTab moves to the tab delta positions away, keeping the cursor on the same PR if it is still listed
+func (m *model) switchTab(delta int) {
+	prevSelection := m.captureCurrentSelection()
+
+	n := len(statusTabs)
+	m.tab = statusTabs[((int(m.tab)+delta)%n+n)%n]
+	m.applyTab()
+	m.restoreCursorPosition(prevSelection)
+}
+
+// tabCounts returns the number of PRs matching the search query in each tab
+func (m model) tabCounts() map[statusTab]int {
+	counts := make(map[statusTab]int, len(statusTabs))
+	for _, pr := range m.matched {
+		for _, tab := range statusTabs {
+			if tab.matches(pr) {
+				counts[tab]++
+			}
+		}
+	}
+	return counts
+}
+
+// buildRows lays out the listed PRs as rows.
+// The tree view groups them under repository headers, ordered by the
+// repository health ranking so that the repositories to act on come first.
+func (m *model) buildRows() {
+	rows := make([]listRow, 0, len(m.filtered))
+	if !m.tree {
+		for _, pr := range m.filtered {
+			rows = append(rows, listRow{pr: pr, repo: pr.Repository})
+		}
+	} else {
+		byRepo := make(map[string][]models.PullRequest)
+		for _, pr := range m.filtered {
+			byRepo[pr.Repository] = append(byRepo[pr.Repository], pr)
+		}
+
+		// Rank by the health of all PRs so that the order doesn't change between tabs
+		for _, h := range health.Compute(m.prs, time.Now()) {
+			prs, ok := byRepo[h.Repository]
+			if !ok {
+				continue
+			}
+			collapsed := m.collapsed[h.Repository]
+			rows = append(rows, listRow{header: true, repo: h.Repository, count: len(prs), collapsed: collapsed})
+			if collapsed {
+				continue
+			}
+			for _, pr := range prs {
+				rows = append(rows, listRow{pr: pr, repo: pr.Repository})
+			}
+		}
+	}
+	m.rows = rows
+
+	// Don't reset cursor to 0 - just adjust if out of bounds
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// currentRow returns the row under the cursor (false if the list is empty)
+func (m *model) currentRow() (listRow, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return listRow{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// currentPR returns the PR under the cursor (false on repository headers and empty lists)
+func (m *model) currentPR() (models.PullRequest, bool) {
+	row, ok := m.currentRow()
+	if !ok || row.header {
+		return models.PullRequest{}, false
+	}
+	return row.pr, true
+}
+
+// toggleTree switches between the flat list and the repository tree
+func (m *model) toggleTree() {
+	prevSelection := m.captureCurrentSelection()
+
+	m.tree = !m.tree
+	m.buildRows()
+	m.restoreCursorPosition(prevSelection)
+}
+
+// toggleGroup collapses or expands the repository under the cursor in the tree view
+func (m *model) toggleGroup() {
+	row, ok := m.currentRow()
+	if !m.tree || !ok {
+		return
+	}
+
+	if m.collapsed[row.repo] {
+		delete(m.collapsed, row.repo)
+	} else {
+		m.collapsed[row.repo] = true
+	}
+	m.buildRows()
+
+	// Keep the cursor on the repository header
+	m.restoreCursorPosition(&PRIdentifier{Repository: row.repo})
+}
+
+// renderStatusTabs renders the status tabs with the number of PRs matching the search query
+func (m model) renderStatusTabs() string {
+	counts := m.tabCounts()
+
+	parts := make([]string, 0, len(statusTabs))
+	for _, tab := range statusTabs {
+		label := fmt.Sprintf("%s (%d)", tab, counts[tab])
+		if tab == m.tab {
+			parts = append(parts, selectedStyle.Render("["+label+"]"))
+		} else {
+			parts = append(parts, dimStyle.Render(" "+label+" "))
+		}
+	}
+
+	layout := "tree"
+	if m.tree {
+		layout = "flat list"
+	}
+	return strings.Join(parts, " ") + dimStyle.Render(fmt.Sprintf("  (%s, %s to switch, %s for %s)",
+		m.keys.hint(modeList, "prev_tab"), m.keys.hint(modeList, "next_tab"), m.keys.hint(modeList, "tree"), layout))
+}
+
+// formatGroupLine formats a repository header of the tree view
+func formatGroupLine(row listRow) string {
+	symbol := icon.expanded
+	if row.collapsed {
+		symbol = icon.collapsed
+	}
+	return fmt.Sprintf("%s %s (%d PR(s))", symbol, row.repo, row.count)
+}
@@ -0,0 +1,187 @@
+package interactive
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// theme is a color palette of the TUI (ANSI color numbers)
+type theme struct {
+	accent     string // Title and repository headers
+	background string // Title, cursor and modal background
+	cursor     string // Cursor row and merge modal
+	rebase     string // Rebase modal
+	text       string // PR rows
+	dim        string // Hints and rows with an operation in flight
+	failure    string // Errors
+	success    string // Successes
+	added      string // Added diff lines
+	deleted    string // Deleted diff lines
+	hunk       string // Diff hunk headers
+	file       string // Diff file headers
+	fileBg     string // Diff file header background
+	markdown   string // glamour style of PR bodies
+	syntax     string // chroma style of code in the diff view
+}
+
+// defaultTheme is the theme used when none is configured
+const defaultTheme = "dark"
+
+// themes are the built-in color palettes
+var themes = map[string]theme{
+	"dark": {
+		accent: "99", background: "235", cursor: "170", rebase: "214", text: "252", dim: "240",
+		failure: "196", success: "46", added: "42", deleted: "203", hunk: "75", file: "252", fileBg: "237",
+		markdown: "dark", syntax: "monokai",
+	},
+	"light": {
+		accent: "55", background: "254", cursor: "126", rebase: "130", text: "235", dim: "244",
+		failure: "160", success: "28", added: "28", deleted: "160", hunk: "25", file: "235", fileBg: "252",
+		markdown: "light", syntax: "github",
+	},
+	// Bright colors of the 16-color palette, which terminal color schemes keep distinguishable
+	"high-contrast": {
+		accent: "14", background: "0", cursor: "11", rebase: "13", text: "15", dim: "7",
+		failure: "9", success: "10", added: "10", deleted: "9", hunk: "14", file: "0", fileBg: "15",
+		markdown: "dark", syntax: "bw",
+	},
+}
+
+// icons are the symbols of the TUI chrome
+type icons struct {
+	cursor     string // Cursor row
+	selected   string // PR selected for bulk actions
+	polling    string // Repository being polled
+	busy       string // PR with an operation in flight
+	expanded   string // Repository header of the tree view
+	collapsed  string // Collapsed repository header of the tree view
+	ascending  string // Ascending sort key
+	descending string // Descending sort key
+}
+
+var (
+	unicodeIcons = icons{cursor: "❯", selected: "●", polling: "↻", busy: "⟳", expanded: "▾", collapsed: "▸", ascending: "↑", descending: "↓"}
+	asciiIcons   = icons{cursor: ">", selected: "*", polling: "@", busy: "~", expanded: "-", collapsed: "+", ascending: "asc", descending: "desc"}
+)
+
+// Styles, set by applyTheme
+var (
+	headerStyle      lipgloss.Style
+	selectedStyle    lipgloss.Style
+	rebaseModalStyle lipgloss.Style
+	normalStyle      lipgloss.Style
+	dimStyle         lipgloss.Style
+	errorStyle       lipgloss.Style
+	successStyle     lipgloss.Style
+	pollingStyle     lipgloss.Style
+	groupStyle       lipgloss.Style
+	diffAddStyle     lipgloss.Style
+	diffDeleteStyle  lipgloss.Style
+	diffHunkStyle    lipgloss.Style
+	diffFileStyle    lipgloss.Style
+
+	markdownStyle string // glamour style of PR bodies
+	syntaxStyle   string // chroma style of diff code ("" = no highlighting)
+	icon          icons  // Symbols of the TUI chrome
+)
+
+func init() {
+	applyTheme(defaultTheme, false, false)
+}
+
+// ValidateTheme returns an error if name is not a built-in theme ("" = dark)
+func ValidateTheme(name string) error {
+	if _, ok := themes[name]; ok || name == "" {
+		return nil
+	}
+	names := make([]string, 0, len(themes))
+	for theme := range themes {
+		names = append(names, theme)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown theme: %s (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+// applyTheme sets the styles from a theme.
+// Without colors (NO_COLOR), only bold and italic text remain, and ASCII
+// mode replaces the symbols of the TUI chrome.
+func applyTheme(name string, noColor, ascii bool) {
+	t, ok := themes[name]
+	if !ok {
+		t = themes[defaultTheme]
+	}
+	c := func(ansi string) color.Color {
+		if noColor {
+			return lipgloss.NoColor{}
+		}
+		return lipgloss.Color(ansi)
+	}
+
+	headerStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(c(t.accent)).
+		Background(c(t.background)).
+		Padding(0, 1)
+
+	selectedStyle = lipgloss.NewStyle().
+		Foreground(c(t.cursor)).
+		Background(c(t.background)).
+		Bold(true)
+
+	rebaseModalStyle = lipgloss.NewStyle().
+		Foreground(c(t.rebase)).
+		Background(c(t.background)).
+		Bold(true)
+
+	normalStyle = lipgloss.NewStyle().
+		Foreground(c(t.text))
+
+	dimStyle = lipgloss.NewStyle().
+		Foreground(c(t.dim))
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(c(t.failure)).
+		Bold(true)
+
+	successStyle = lipgloss.NewStyle().
+		Foreground(c(t.success)).
+		Bold(true)
+
+	pollingStyle = lipgloss.NewStyle().
+		Foreground(c(t.dim)).
+		Italic(true)
+
+	groupStyle = lipgloss.NewStyle().
+		Foreground(c(t.accent)).
+		Bold(true)
+
+	diffAddStyle = lipgloss.NewStyle().
+		Foreground(c(t.added))
+
+	diffDeleteStyle = lipgloss.NewStyle().
+		Foreground(c(t.deleted))
+
+	diffHunkStyle = lipgloss.NewStyle().
+		Foreground(c(t.hunk))
+
+	diffFileStyle = lipgloss.NewStyle().
+		Foreground(c(t.file)).
+		Background(c(t.fileBg)).
+		Bold(true)
+
+	markdownStyle = t.markdown
+	syntaxStyle = t.syntax
+	if noColor {
+		markdownStyle = "notty"
+		syntaxStyle = ""
+	}
+
+	icon = unicodeIcons
+	if ascii {
+		icon = asciiIcons
+	}
+}
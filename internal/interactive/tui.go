@@ -6,51 +6,16 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
-	"sort"
 	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
-	"charm.land/lipgloss/v2"
 	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/audit"
+	"github.com/swfz/gh-deps/internal/filter"
+	"github.com/swfz/gh-deps/internal/formatter"
 	"github.com/swfz/gh-deps/internal/models"
-)
-
-// Styles
-var (
-	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("99")).
-			Background(lipgloss.Color("235")).
-			Padding(0, 1)
-
-	selectedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("170")).
-			Background(lipgloss.Color("235")).
-			Bold(true)
-
-	rebaseModalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
-			Background(lipgloss.Color("235")).
-			Bold(true)
-
-	normalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-
-	dimStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240"))
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-
-	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("46")).
-			Bold(true)
-
-	pollingStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")).
-			Italic(true)
+	"github.com/swfz/gh-deps/internal/sorter"
 )
 
 // PRIdentifier uniquely identifies a PR by repository and number
@@ -79,29 +44,47 @@ type pollState struct {
 
 // model represents the TUI state
 type model struct {
-	prs            []models.PullRequest  // All PRs
-	filtered       []models.PullRequest  // Filtered PRs based on search
-	cursor         int                   // Current cursor position
-	query          string                // Search query
-	searchMode     bool                  // Whether in search mode
-	confirmMode    bool                  // Whether in confirmation mode
-	confirmingPR   *models.PullRequest   // PR being confirmed for merge
-	confirmRebase  bool                  // Whether confirming rebase instead of merge
-	client         *api.Client           // API client for merging
-	ctx            context.Context       // Context for API calls
-	target         string                // Target org/user for refresh
-	isOrganization bool                  // Whether target is org
-	limit          int                   // PR limit for refresh
-	verbose        bool                  // Verbose mode
-	message        string                // Status message
-	messageType    string                // "error", "success", or ""
-	width          int                   // Terminal width
-	height         int                   // Terminal height
-	merging        bool                     // Whether currently merging
-	refreshing     bool                     // Whether currently refreshing PRs
-	rebasing       bool                     // Whether currently triggering rebase
-	done           bool                     // Whether to quit
-	pollingRepos   map[string]*pollState    // Track which repos are being polled
+	prs           []models.PullRequest       // All PRs
+	matched       []models.PullRequest       // PRs matching the search query
+	filtered      []models.PullRequest       // PRs matching the search query in the current tab
+	rows          []listRow                  // Rows of the PR list (PRs and, in the tree view, repository headers)
+	cursor        int                        // Current cursor position in rows
+	tab           statusTab                  // Current status tab
+	tree          bool                       // Whether PRs are grouped under repository headers
+	collapsed     map[string]bool            // Collapsed repositories in the tree view
+	query         string                     // Search query (filter expression)
+	queryErr      string                     // Parse error of the search query
+	searchMode    bool                       // Whether in search mode
+	confirmMode   bool                       // Whether in confirmation mode
+	confirmingPR  *models.PullRequest        // PR being confirmed for merge
+	confirmRebase bool                       // Whether confirming rebase instead of merge
+	client        *api.Client                // API client for merging
+	ctx           context.Context            // Context for API calls
+	fetcher       *api.Fetcher               // PR selection for refresh and polling
+	verbose       bool                       // Verbose mode
+	mergeMethod   string                     // Merge method (merge, squash, rebase)
+	keys          keyMap                     // Active keybindings per mode
+	sortKeys      []sorter.Key               // Current sort keys (first key is cycled with s/S)
+	view          viewMode                   // Current view (PRs or repositories)
+	repoCursor    int                        // Cursor position in the repositories view
+	actions       []actionEntry              // Actions taken in this session, oldest first
+	actionCursor  int                        // Cursor position in the action log (0 = newest)
+	audit         *audit.Log                 // Audit log the actions are appended to (nil = disabled)
+	auditErr      error                      // Last error writing the audit log
+	xOffset       int                        // Horizontal scroll position of the PR list
+	message       string                     // Status message
+	messageType   string                     // "error", "success", or ""
+	width         int                        // Terminal width
+	height        int                        // Terminal height
+	refreshing    bool                       // Whether currently refreshing PRs
+	operations    map[PRIdentifier]operation // Operations in flight, by the PR they target
+	done          bool                       // Whether to quit
+	pollingRepos  map[string]*pollState      // Track which repos are being polled
+	selected      map[PRIdentifier]bool      // PRs selected for bulk actions
+	bulk          *bulkOperation             // Bulk action being confirmed or run (nil = none)
+	detail        *detailState               // Detail view of a PR (nil = closed)
+	diff          *diffState                 // Diff view of a PR (nil = closed)
+	help          *pager                     // Help overlay (nil = closed)
 }
 
 // Init initializes the model
@@ -115,69 +98,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.scrollHorizontally(0) // Keep the scroll position within the new width
+		if m.detail != nil {
+			m.renderDetail()
+		}
+		if m.diff != nil {
+			m.renderDiff()
+		}
 		return m, nil
 
-	case mergeResultMsg:
-		m.merging = false
-		m.message = msg.message
-		if msg.success {
-			m.messageType = "success"
-
-			// Capture merged PR repository before removal
-			var mergedRepo string
-			if m.cursor < len(m.filtered) {
-				mergedRepo = m.filtered[m.cursor].Repository
-
-				// Find and remove from both filtered and original list
-				mergedPR := m.filtered[m.cursor]
-				m.filtered = append(m.filtered[:m.cursor], m.filtered[m.cursor+1:]...)
-				// Also remove from original prs list
-				for i, pr := range m.prs {
-					if pr.Number == mergedPR.Number && pr.Repository == mergedPR.Repository {
-						m.prs = append(m.prs[:i], m.prs[i+1:]...)
-						break
-					}
-				}
-				// Adjust cursor
-				if m.cursor >= len(m.filtered) && m.cursor > 0 {
-					m.cursor--
-				}
-			}
+	case tea.MouseClickMsg:
+		return m.handleMouseClick(msg.Mouse())
 
-			// Start refreshing PRs after successful merge
-			m.refreshing = true
-			m.message = "Refreshing PRs..."
-			m.messageType = ""
+	case tea.MouseWheelMsg:
+		return m.handleMouseWheel(msg.Mouse())
 
-			// Also start polling the merged repository
-			if mergedRepo != "" {
-				return m, tea.Batch(
-					m.refreshPRs(),
-					m.startPolling(mergedRepo, pollInitialBackoff),
-				)
-			}
+	case detailLoadedMsg:
+		return m.handleDetailLoaded(msg)
 
-			return m, m.refreshPRs()
-		} else {
-			m.messageType = "error"
-		}
-		return m, nil
+	case diffLoadedMsg:
+		return m.handleDiffLoaded(msg)
 
-	case rebaseResultMsg:
-		m.rebasing = false
-		m.message = msg.message
-		if msg.success {
-			m.messageType = "success"
+	case operationResultMsg:
+		return m.handleOperationResult(msg)
 
-			// Start polling the rebased repository with longer initial delay
-			// (CI takes longer to restart after rebase)
-			if msg.repository != "" {
-				return m, m.startPolling(msg.repository, pollRebaseInitialBackoff)
-			}
-		} else {
-			m.messageType = "error"
-		}
-		return m, nil
+	case undoResultMsg:
+		return m.handleUndoResult(msg)
 
 	case refreshPRsMsg:
 		m.refreshing = false
@@ -191,10 +137,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update PR list with new data
 		m.prs = msg.prs
 
-		// Sort by repository name (same as initial display)
-		sort.Slice(m.prs, func(i, j int) bool {
-			return m.prs[i].RepoName() < m.prs[j].RepoName()
-		})
+		// Sort with the current sort keys (same as initial display)
+		sorter.Sort(m.prs, m.sortKeys)
 
 		// Re-apply search filter to new data
 		m.filterPRs()
@@ -207,6 +151,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case bulkResultMsg:
+		return m.handleBulkResult(msg)
+
 	case pollTimerMsg:
 		// Timer expired, execute poll
 		return m, m.pollRepository(msg.repository)
@@ -258,169 +205,266 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.messageType = ""
 		}
 
-		switch msg.String() {
-		case "ctrl+c":
+		key := msg.String()
+		if key == "ctrl+c" {
 			m.done = true
 			return m, tea.Quit
+		}
 
-		case "q", "esc":
-			if m.confirmMode {
-				// Cancel confirmation
-				m.confirmMode = false
-				m.confirmingPR = nil
-				m.confirmRebase = false
+		// The help overlay is closed with any key
+		if m.help != nil {
+			return m.updateHelp(key)
+		}
+
+		// The bulk modal consumes keys until the action is confirmed,
+		// and a finished bulk action is dismissed with any key
+		if m.bulk != nil {
+			if m.bulk.finished() {
+				m.bulk = nil
 				return m, nil
 			}
-			if m.searchMode {
-				m.searchMode = false
-				m.query = ""
-				m.filterPRs()
-				return m, nil
+			if !m.bulk.started {
+				return m.updateBulkConfirm(key)
 			}
+		}
+
+		switch {
+		case m.diff != nil:
+			return m.updateDiffView(key)
+		case m.detail != nil:
+			return m.updateDetailView(key)
+		case m.view == viewRepositories:
+			return m.updateRepositoryView(key)
+		case m.view == viewActions:
+			return m.updateActionLog(key)
+		case m.confirmMode:
+			return m.updateConfirm(key)
+		case m.searchMode:
+			return m.updateSearch(key)
+		default:
+			return m.updateList(key)
+		}
+	}
+
+	return m, nil
+}
+
+// updateList handles key presses in the PR list
+func (m model) updateList(key string) (tea.Model, tea.Cmd) {
+	switch m.keys.action(modeList, key) {
+	case "quit":
+		m.done = true
+		return m, tea.Quit
+
+	case "help":
+		m.openHelp()
+
+	case "clear":
+		// Clear the selection, then the filter, before quitting
+		switch {
+		case len(m.selected) > 0:
+			m.selected = make(map[PRIdentifier]bool)
+		case m.query != "":
+			m.query = ""
+			m.filterPRs()
+		default:
 			m.done = true
 			return m, tea.Quit
+		}
 
-		case "/":
-			if !m.confirmMode {
-				m.searchMode = true
-			}
-			return m, nil
+	case "switch_view":
+		m.view = viewRepositories
 
-		case "r":
-			// Manual refresh - only if not in search/confirm/merging/refreshing mode
-			if !m.searchMode && !m.confirmMode && !m.merging && !m.refreshing {
-				m.refreshing = true
-				m.message = "Refreshing PRs..."
-				m.messageType = ""
-				return m, m.refreshPRs()
-			}
-			return m, nil
+	case "action_log":
+		m.view = viewActions
 
-		case "o":
-			// Open PR in browser - only if not in search/confirm mode
-			if !m.searchMode && !m.confirmMode && len(m.filtered) > 0 && m.cursor < len(m.filtered) {
-				pr := m.filtered[m.cursor]
-				if err := openBrowser(pr.URL); err != nil {
-					m.message = fmt.Sprintf("Failed to open browser: %v", err)
-					m.messageType = "error"
-				} else {
-					m.message = fmt.Sprintf("Opened PR #%d in browser", pr.Number)
-					m.messageType = "success"
-				}
-			}
-			return m, nil
+	case "search":
+		m.searchMode = true
 
-		case "enter", "y":
-			if m.confirmMode {
-				if m.confirmingPR != nil && !m.merging && !m.rebasing {
-					pr := *m.confirmingPR
-					m.confirmMode = false
-					m.confirmingPR = nil
-
-					// Check if we're in rebase mode
-					if m.confirmRebase {
-						m.rebasing = true
-						m.message = "Triggering rebase..."
-						m.messageType = ""
-						m.confirmRebase = false
-						return m, m.rebasePR(pr)
-					} else {
-						// Normal merge
-						m.merging = true
-						m.message = "Merging..."
-						m.messageType = ""
-						return m, m.mergePR(pr)
-					}
-				}
+	case "up":
+		m.moveCursor(-1)
+
+	case "down":
+		m.moveCursor(1)
+
+	case "half_page_up":
+		m.moveCursor(-m.getPageSize() / 2)
+
+	case "half_page_down":
+		m.moveCursor(m.getPageSize() / 2)
+
+	case "page_up":
+		m.moveCursor(-m.getPageSize())
+
+	case "page_down":
+		m.moveCursor(m.getPageSize())
+
+	case "top":
+		m.moveCursor(-len(m.rows))
+
+	case "bottom":
+		m.moveCursor(len(m.rows))
+
+	case "scroll_left":
+		m.scrollHorizontally(-horizontalScrollBy)
+
+	case "scroll_right":
+		m.scrollHorizontally(horizontalScrollBy)
+
+	case "next_tab":
+		m.switchTab(1)
+
+	case "prev_tab":
+		m.switchTab(-1)
+
+	case "tree":
+		m.toggleTree()
+
+	case "toggle_group":
+		m.toggleGroup()
+
+	case "refresh":
+		// Manual refresh - only if not already refreshing
+		if !m.refreshing {
+			m.refreshing = true
+			m.message = "Refreshing PRs..."
+			m.messageType = ""
+			return m, m.refreshPRs()
+		}
+
+	case "rerun":
+		if pr, ok := m.currentPR(); ok {
+			if pr.CheckSummary.Status != models.StatusFailure {
+				m.message = fmt.Sprintf("PR #%d has no failing checks", pr.Number)
+				m.messageType = "error"
 				return m, nil
 			}
-			// Show confirmation modal
-			if len(m.filtered) > 0 && m.cursor < len(m.filtered) {
-				m.confirmMode = true
-				pr := m.filtered[m.cursor]
-				m.confirmingPR = &pr
-
-				// Determine if this should be a rebase or merge
-				// If PR has conflicts and bot supports rebase, offer rebase
-				if pr.MergeableState == models.MergeableStateConflicting && pr.BotType.SupportsRebase() {
-					m.confirmRebase = true
-				} else {
-					m.confirmRebase = false
-				}
+			return m, m.startOperation(pr, opRerun, "")
+		}
+
+	case "sort":
+		m.cycleSortField()
+
+	case "sort_direction":
+		m.toggleSortDirection()
+
+	case "select":
+		m.toggleSelection()
+
+	case "select_all":
+		m.selectAllVisible()
+
+	case "invert":
+		m.invertSelection()
+
+	case "detail":
+		return m, m.openDetail()
+
+	case "diff":
+		if pr, ok := m.currentPR(); ok {
+			return m, m.openDiff(pr)
+		}
+
+	case "open":
+		if pr, ok := m.currentPR(); ok {
+			if err := openBrowser(pr.URL); err != nil {
+				m.message = fmt.Sprintf("Failed to open browser: %v", err)
+				m.messageType = "error"
+			} else {
+				m.message = fmt.Sprintf("Opened PR #%d in browser", pr.Number)
+				m.messageType = "success"
 			}
-			return m, nil
+		}
 
-		case "n":
-			if m.confirmMode {
-				// Cancel confirmation
-				m.confirmMode = false
-				m.confirmingPR = nil
-				m.confirmRebase = false
+	case "merge":
+		// Selected PRs go through the bulk confirmation modal
+		if len(m.selected) > 0 {
+			if m.bulk == nil {
+				m.openBulkConfirm()
 			}
 			return m, nil
+		}
+		// Repository headers of the tree view are expanded and collapsed instead
+		if row, ok := m.currentRow(); ok && row.header {
+			m.toggleGroup()
+			return m, nil
+		}
+		m.openConfirm()
+	}
 
-		case "up", "k":
-			if !m.searchMode && !m.confirmMode && m.cursor > 0 {
-				m.cursor--
-			}
+	return m, nil
+}
 
-		case "down", "j":
-			if !m.searchMode && !m.confirmMode && m.cursor < len(m.filtered)-1 {
-				m.cursor++
-			}
+// updateSearch handles key presses while typing a search query
+func (m model) updateSearch(key string) (tea.Model, tea.Cmd) {
+	switch m.keys.action(modeSearch, key) {
+	case "cancel":
+		m.searchMode = false
+		m.query = ""
+		m.filterPRs()
 
-		case "ctrl+k":
-			// Cursor movement in search mode
-			if m.searchMode && m.cursor > 0 {
-				m.cursor--
-			}
+	case "up":
+		m.moveCursor(-1)
 
-		case "ctrl+j":
-			// Cursor movement in search mode
-			if m.searchMode && m.cursor < len(m.filtered)-1 {
-				m.cursor++
-			}
+	case "down":
+		m.moveCursor(1)
 
-		case "ctrl+u":
-			// Half page up
-			if !m.searchMode && !m.confirmMode {
-				halfPage := m.getPageSize() / 2
-				m.moveCursor(-halfPage)
-			}
+	case "delete_char":
+		if len(m.query) > 0 {
+			runes := []rune(m.query)
+			m.query = string(runes[:len(runes)-1])
+			m.filterPRs()
+		}
 
-		case "ctrl+d":
-			// Half page down
-			if !m.searchMode && !m.confirmMode {
-				halfPage := m.getPageSize() / 2
-				m.moveCursor(halfPage)
-			}
+	case "merge":
+		m.openConfirm()
 
-		case "ctrl+f":
-			// Full page down
-			if !m.searchMode && !m.confirmMode {
-				fullPage := m.getPageSize()
-				m.moveCursor(fullPage)
-			}
+	default:
+		// Other printable keys are typed into the query
+		if key == "space" {
+			key = " "
+		}
+		if len([]rune(key)) == 1 {
+			m.query += key
+			m.filterPRs()
+		}
+	}
 
-		case "ctrl+b":
-			// Full page up
-			if !m.searchMode && !m.confirmMode {
-				fullPage := m.getPageSize()
-				m.moveCursor(-fullPage)
-			}
+	return m, nil
+}
 
-		case "backspace":
-			if m.searchMode && len(m.query) > 0 {
-				m.query = m.query[:len(m.query)-1]
-				m.filterPRs()
-			}
+// openConfirm shows the confirmation modal for the PR under the cursor
+func (m *model) openConfirm() {
+	pr, ok := m.currentPR()
+	if !ok {
+		return
+	}
+	m.confirmMode = true
+	m.confirmingPR = &pr
 
-		default:
-			if m.searchMode && !m.confirmMode && len(msg.String()) == 1 {
-				m.query += msg.String()
-				m.filterPRs()
-			}
+	// Offer a rebase instead of a merge if the PR has conflicts and the bot supports it
+	m.confirmRebase = pr.MergeableState == models.MergeableStateConflicting && pr.BotType.SupportsRebase()
+}
+
+// updateConfirm handles key presses in the confirmation modal
+func (m model) updateConfirm(key string) (tea.Model, tea.Cmd) {
+	switch m.keys.action(modeConfirm, key) {
+	case "confirm":
+		pr := *m.confirmingPR
+		rebase := m.confirmRebase
+		m.confirmMode = false
+		m.confirmingPR = nil
+		m.confirmRebase = false
+
+		if rebase {
+			return m, m.startOperation(pr, opRebase, "")
 		}
+		return m, m.startOperation(pr, opMerge, "")
+
+	case "cancel":
+		m.confirmMode = false
+		m.confirmingPR = nil
+		m.confirmRebase = false
 	}
 
 	return m, nil
@@ -434,88 +478,121 @@ func (m model) View() tea.View {
 
 	var b strings.Builder
 
-	// Header
-	header := headerStyle.Render(" gh-deps Interactive Mode ")
-	b.WriteString(header + "\n")
-	b.WriteString(dimStyle.Render("  Use ↑/↓ or j/k to navigate, Ctrl+U/D (half page), Ctrl+F/B (full page), / to search (Ctrl+J/K in search), o to open in browser, r to refresh, Enter to merge, q to quit") + "\n\n")
-
-	// Search bar
-	if m.searchMode {
-		b.WriteString(fmt.Sprintf("Search: %s█ (Ctrl+J/K to navigate, Esc to exit)\n\n", m.query))
-	} else if m.query != "" {
-		b.WriteString(dimStyle.Render(fmt.Sprintf("Filter: %s (press / to edit, Esc to clear)", m.query)) + "\n\n")
+	// Help overlay replaces everything below the tabs
+	if m.help != nil {
+		b.WriteString(m.renderHeader())
+		b.WriteString(m.renderHelp())
+		return newView(b.String())
 	}
 
-	// Status message
-	if m.message != "" {
-		switch m.messageType {
-		case "error":
-			b.WriteString(errorStyle.Render("✗ "+m.message) + "\n\n")
-		case "success":
-			b.WriteString(successStyle.Render("✓ "+m.message) + "\n\n")
-		default:
-			// refreshing中はこちら
-			if m.refreshing {
-				b.WriteString(dimStyle.Render("⟳ "+m.message) + "\n\n")
-			} else {
-				b.WriteString(m.message + "\n\n")
-			}
-		}
+	// Diff view replaces the PR list and the detail view
+	if m.diff != nil {
+		b.WriteString(m.renderHeader())
+		m.writeMessage(&b)
+		b.WriteString(m.renderDiffView())
+		return newView(b.String())
 	}
 
-	// PR list header
-	listHeader := fmt.Sprintf("%-4s %-20s %-12s %-4s %-6s %-15s %-12s %s",
-		"#", "REPO", "BOT", "CI", "MERGE", "LABELS", "VERSION", "TITLE")
-	b.WriteString(dimStyle.Render(listHeader) + "\n")
-	b.WriteString(strings.Repeat("─", m.width) + "\n")
+	// Detail view replaces the PR list
+	if m.detail != nil {
+		b.WriteString(m.renderHeader())
+		m.writeMessage(&b)
+		b.WriteString(m.renderDetailView())
+		return newView(b.String())
+	}
 
-	// PR list (limited to visible area)
-	maxVisible := m.height - 10 // Reserve space for header, footer, etc.
-	if maxVisible < 5 {
-		maxVisible = 5
+	// Repositories view replaces the PR list
+	if m.view == viewRepositories {
+		b.WriteString(m.renderHeader())
+		m.writeMessage(&b)
+		b.WriteString(m.renderRepositoryView())
+		return newView(b.String())
 	}
 
-	startIdx := m.cursor - maxVisible/2
-	if startIdx < 0 {
-		startIdx = 0
+	// Action log replaces the PR list
+	if m.view == viewActions {
+		b.WriteString(m.renderHeader())
+		m.writeMessage(&b)
+		b.WriteString(m.renderActionLog())
+		return newView(b.String())
 	}
-	endIdx := startIdx + maxVisible
-	if endIdx > len(m.filtered) {
-		endIdx = len(m.filtered)
-		startIdx = endIdx - maxVisible
-		if startIdx < 0 {
-			startIdx = 0
+
+	b.WriteString(m.renderListTop())
+
+	// PR list (limited to visible area)
+	startIdx, endIdx := m.visibleRows()
+
+	// PRs are numbered without the repository headers of the tree view
+	headers := 0
+	for _, row := range m.rows[:startIdx] {
+		if row.header {
+			headers++
 		}
 	}
 
 	for i := startIdx; i < endIdx; i++ {
-		pr := m.filtered[i]
-		line := m.formatPRLine(i+1, pr)
+		row := m.rows[i]
+		if row.header {
+			headers++
+			line := formatGroupLine(row)
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render(icon.cursor+" "+line) + "\n")
+			} else {
+				b.WriteString(groupStyle.Render("  "+line) + "\n")
+			}
+			continue
+		}
+
+		pr := row.pr
+		line := m.formatPRLine(i+1-headers, pr)
 
 		_, isPolling := m.pollingRepos[pr.Repository]
+		if _, isBusy := m.operations[identify(pr)]; isBusy {
+			isPolling = true // Dim PRs with an operation in flight like polled ones
+		}
+
+		// Selected PRs are marked for bulk actions
+		mark := " "
+		if m.selected[identify(pr)] {
+			mark = icon.selected
+		}
 
 		if i == m.cursor {
 			if isPolling {
 				// Selected and polling: combine styles
-				b.WriteString(selectedStyle.Render("❯ ") + pollingStyle.Render(line) + "\n")
+				b.WriteString(selectedStyle.Render(icon.cursor+mark) + pollingStyle.Render(line) + "\n")
 			} else {
-				b.WriteString(selectedStyle.Render("❯ " + line) + "\n")
+				b.WriteString(selectedStyle.Render(icon.cursor+mark+line) + "\n")
 			}
 		} else {
 			if isPolling {
 				// Polling: dimmed style with icon
-				b.WriteString(pollingStyle.Render("  " + line) + "\n")
+				b.WriteString(pollingStyle.Render(" "+mark+line) + "\n")
 			} else {
-				b.WriteString(normalStyle.Render("  " + line) + "\n")
+				b.WriteString(normalStyle.Render(" "+mark+line) + "\n")
 			}
 		}
 	}
 
 	// Footer
 	if len(m.filtered) == 0 {
-		b.WriteString("\n" + dimStyle.Render("  No PRs match your filter") + "\n")
+		if m.tab != tabAll {
+			b.WriteString("\n" + dimStyle.Render(fmt.Sprintf("  No PRs in %s match your filter", m.tab)) + "\n")
+		} else {
+			b.WriteString("\n" + dimStyle.Render("  No PRs match your filter") + "\n")
+		}
 	} else {
-		footer := fmt.Sprintf("  %d/%d PRs", m.cursor+1, len(m.filtered))
+		position := fmt.Sprintf("%d/%d PRs", m.cursor+1, len(m.filtered))
+		if m.tree {
+			position = fmt.Sprintf("%d/%d rows, %d PRs", m.cursor+1, len(m.rows), len(m.filtered))
+		}
+		footer := fmt.Sprintf("  %s  |  Sort: %s", position, formatSortKeys(m.sortKeys))
+		if lineWidth(m.columnWidths()) > m.width-listPrefixWidth {
+			footer += fmt.Sprintf("  |  %s/%s to scroll columns", m.keys.hint(modeList, "scroll_left"), m.keys.hint(modeList, "scroll_right"))
+		}
+		if len(m.selected) > 0 {
+			footer += fmt.Sprintf("  |  Selected: %d", len(m.selected))
+		}
 
 		// Add polling indicator
 		if len(m.pollingRepos) > 0 {
@@ -529,6 +606,15 @@ func (m model) View() tea.View {
 		b.WriteString("\n" + dimStyle.Render(footer) + "\n")
 	}
 
+	// Bulk action modal or progress
+	if m.bulk != nil {
+		if m.bulk.started {
+			b.WriteString(m.renderBulkProgress())
+		} else {
+			b.WriteString(m.renderBulkConfirm())
+		}
+	}
+
 	// Confirmation modal overlay
 	if m.confirmMode && m.confirmingPR != nil {
 		pr := *m.confirmingPR
@@ -546,14 +632,14 @@ func (m model) View() tea.View {
 		}
 
 		modal.WriteString("╠═══════════════════════════════════════════════════════════════╣\n")
-		modal.WriteString(fmt.Sprintf("║ Repository: %-49s ║\n", pr.Repository))
+		modal.WriteString(fmt.Sprintf("║ Repository: %s ║\n", formatter.PadToWidth(pr.Repository, 49)))
 		modal.WriteString(fmt.Sprintf("║ PR Number:  #%-47d ║\n", pr.Number))
-		modal.WriteString(fmt.Sprintf("║ Title:      %-49s ║\n", truncate(pr.Title, 49)))
-		modal.WriteString(fmt.Sprintf("║ URL:        %-49s ║\n", truncate(pr.URL, 49)))
-		modal.WriteString(fmt.Sprintf("║ Bot:        %-49s ║\n", pr.BotType.DisplayName()))
-		modal.WriteString(fmt.Sprintf("║ Version:    %-49s ║\n", pr.Version))
-		modal.WriteString(fmt.Sprintf("║ CI Status:  %-49s ║\n", string(pr.CheckSummary.Status)))
-		modal.WriteString(fmt.Sprintf("║ Mergeable:  %-49s ║\n", formatMergeableState(pr.MergeableState)))
+		modal.WriteString(fmt.Sprintf("║ Title:      %s ║\n", formatter.PadToWidth(pr.Title, 49)))
+		modal.WriteString(fmt.Sprintf("║ URL:        %s ║\n", formatter.PadToWidth(pr.URL, 49)))
+		modal.WriteString(fmt.Sprintf("║ Bot:        %s ║\n", formatter.PadToWidth(pr.BotType.DisplayName(), 49)))
+		modal.WriteString(fmt.Sprintf("║ Version:    %s ║\n", formatter.PadToWidth(pr.Version, 49)))
+		modal.WriteString(fmt.Sprintf("║ CI Status:  %s ║\n", formatter.PadToWidth(formatter.CheckStatus(pr.CheckSummary.Status), 49)))
+		modal.WriteString(fmt.Sprintf("║ Mergeable:  %s ║\n", formatter.PadToWidth(formatter.MergeableState(pr.MergeableState), 49)))
 		modal.WriteString("╠═══════════════════════════════════════════════════════════════╣\n")
 
 		// Show warnings or info
@@ -567,11 +653,11 @@ func (m model) View() tea.View {
 		} else {
 			// Show warnings for merge
 			if pr.MergeableState == models.MergeableStateConflicting {
-				modal.WriteString("║ " + errorStyle.Render("⚠ WARNING: This PR has conflicts!") + strings.Repeat(" ", 29) + "║\n")
+				modal.WriteString("║ " + errorStyle.Render(formatter.Symbols().Warning+" WARNING: This PR has conflicts!") + strings.Repeat(" ", 29) + "║\n")
 			} else if pr.CheckSummary.Status == models.StatusFailure {
-				modal.WriteString("║ " + errorStyle.Render("⚠ WARNING: CI checks are failing!") + strings.Repeat(" ", 27) + "║\n")
+				modal.WriteString("║ " + errorStyle.Render(formatter.Symbols().Warning+" WARNING: CI checks are failing!") + strings.Repeat(" ", 27) + "║\n")
 			} else if pr.CheckSummary.Status == models.StatusPending {
-				modal.WriteString("║ ⚠ WARNING: CI checks are pending" + strings.Repeat(" ", 29) + "║\n")
+				modal.WriteString("║ " + formatter.Symbols().Warning + " WARNING: CI checks are pending" + strings.Repeat(" ", 29) + "║\n")
 			}
 		}
 
@@ -579,9 +665,11 @@ func (m model) View() tea.View {
 
 		// Prompt changes based on rebase/merge mode
 		if m.confirmRebase {
-			modal.WriteString("║ Trigger rebase? (y/n or Esc to cancel)                       ║\n")
+			modal.WriteString(fmt.Sprintf("║ %s ║\n", formatter.PadToWidth(fmt.Sprintf("Trigger rebase? (%s to confirm, %s to cancel)",
+				m.keys.hint(modeConfirm, "confirm"), m.keys.hint(modeConfirm, "cancel")), 61)))
 		} else {
-			modal.WriteString("║ Merge this PR? (y/n or Esc to cancel)                        ║\n")
+			modal.WriteString(fmt.Sprintf("║ %s ║\n", formatter.PadToWidth(fmt.Sprintf("Merge this PR? (%s to confirm, %s to cancel)",
+				m.keys.hint(modeConfirm, "confirm"), m.keys.hint(modeConfirm, "cancel")), 61)))
 		}
 
 		modal.WriteString("╚═══════════════════════════════════════════════════════════════╝\n")
@@ -597,180 +685,128 @@ func (m model) View() tea.View {
 		b.WriteString("\n" + modalContent)
 	}
 
-	v := tea.NewView(b.String())
+	return newView(b.String())
+}
+
+// newView creates the full-screen view with mouse input
+func newView(content string) tea.View {
+	v := tea.NewView(content)
 	v.AltScreen = true
+	v.MouseMode = tea.MouseModeCellMotion
 	return v
 }
 
-// formatPRLine formats a single PR line for display
-func (m model) formatPRLine(num int, pr models.PullRequest) string {
-	repo := truncate(pr.RepoName(), 20)
-	bot := truncate(pr.BotType.DisplayName(), 12)
-	ci := string(pr.CheckSummary.Status)
-	merge := formatMergeableState(pr.MergeableState)
-	labels := formatLabels(pr.Labels, 15)
-	version := truncate(pr.Version, 12)
+// renderHeader renders the title, the key hints and the tabs
+func (m model) renderHeader() string {
+	var b strings.Builder
 
-	// Check if repository is being polled
-	pollingIcon := ""
-	if _, isPolling := m.pollingRepos[pr.Repository]; isPolling {
-		pollingIcon = "↻ "
-	}
+	header := headerStyle.Render(" gh-deps Interactive Mode ")
+	b.WriteString(header + "\n")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  %s to search, %s to merge, %s for details, %s for all keybindings, %s to quit",
+		m.keys.hint(modeList, "search"), m.keys.hint(modeList, "merge"), m.keys.hint(modeList, "detail"),
+		m.keys.hint(modeList, "help"), m.keys.hint(modeList, "quit"))) + "\n\n")
 
-	// Calculate title width dynamically based on terminal width
-	// Fixed columns: # (4) + REPO (20) + BOT (12) + CI (4) + MERGE (6) + LABELS (15) + VERSION (12) = 73
-	// Add spaces between columns (~7) and margins (~10) = 90
-	fixedWidth := 90
-	titleWidth := m.width - fixedWidth
-	if titleWidth < 30 {
-		titleWidth = 30 // Minimum width for narrow terminals
+	b.WriteString(m.renderTabs() + "\n")
+	if m.view == viewPullRequests && m.detail == nil && m.diff == nil && m.help == nil {
+		b.WriteString(m.renderStatusTabs() + "\n")
 	}
-	// No maximum limit - use full terminal width
-	title := truncate(pr.Title, titleWidth)
-
-	// Format: PR number with polling icon
-	prNumber := fmt.Sprintf("%s%-4d", pollingIcon, num)
-
-	return fmt.Sprintf("%-6s %-20s %-12s %-4s %-6s %-15s %-12s %s",
-		prNumber, repo, bot, ci, merge, labels, version, title)
+	b.WriteString("\n")
+	return b.String()
 }
 
-// filterPRs filters PRs based on query
-func (m *model) filterPRs() {
-	if m.query == "" {
-		m.filtered = m.prs
-		// Don't reset cursor to 0 - just adjust if out of bounds
-		if m.cursor >= len(m.filtered) {
-			m.cursor = len(m.filtered) - 1
-		}
-		if m.cursor < 0 {
-			m.cursor = 0
-		}
-		return
-	}
-
-	m.filtered = []models.PullRequest{}
-	query := strings.ToLower(m.query)
-
-	for _, pr := range m.prs {
-		// Search in repo name, title, bot type, labels, version
-		searchText := strings.ToLower(fmt.Sprintf("%s %s %s %s %s",
-			pr.RepoName(), pr.Title, pr.BotType.DisplayName(),
-			strings.Join(pr.Labels, " "), pr.Version))
+// renderListTop renders everything above the rows of the PR list.
+// Mouse clicks are mapped to rows by counting its lines.
+func (m model) renderListTop() string {
+	var b strings.Builder
+	b.WriteString(m.renderHeader())
 
-		if strings.Contains(searchText, query) {
-			m.filtered = append(m.filtered, pr)
+	// Search bar
+	if m.searchMode {
+		b.WriteString(fmt.Sprintf("Search: %s█ (%s/%s to navigate, %s to exit)\n", m.query,
+			m.keys.hint(modeSearch, "down"), m.keys.hint(modeSearch, "up"), m.keys.hint(modeSearch, "cancel")))
+		if m.queryErr != "" {
+			b.WriteString(errorStyle.Render("  "+m.queryErr) + "\n")
 		}
+		b.WriteString("\n")
+	} else if m.query != "" {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("Filter: %s (press %s to edit, %s to clear)", m.query,
+			m.keys.hint(modeList, "search"), m.keys.hint(modeList, "clear"))) + "\n\n")
 	}
 
-	// Reset cursor if out of bounds
-	if m.cursor >= len(m.filtered) {
-		m.cursor = len(m.filtered) - 1
-	}
-	if m.cursor < 0 {
-		m.cursor = 0
-	}
-}
-
-// mergePR creates a command to merge the selected PR
-func (m *model) mergePR(pr models.PullRequest) tea.Cmd {
-	return func() tea.Msg {
-		// Check for conflicts
-		if pr.MergeableState == models.MergeableStateConflicting {
-			return mergeResultMsg{
-				success: false,
-				message: fmt.Sprintf("PR #%d has conflicts and cannot be merged", pr.Number),
-			}
-		}
-
-		// Parse repository
-		owner, repo, err := api.ParseRepository(pr.Repository)
-		if err != nil {
-			return mergeResultMsg{
-				success: false,
-				message: fmt.Sprintf("Invalid repository format: %v", err),
-			}
-		}
+	m.writeMessage(&b)
 
-		// Execute merge
-		resp, err := m.client.MergePullRequest(m.ctx, owner, repo, pr.Number)
-		if err != nil {
-			return mergeResultMsg{
-				success: false,
-				message: fmt.Sprintf("Merge failed: %v", err),
-			}
-		}
+	// PR list header
+	b.WriteString(dimStyle.Render(m.formatListHeader()) + "\n")
+	b.WriteString(strings.Repeat("─", m.width) + "\n")
+	return b.String()
+}
 
-		if !resp.Merged {
-			return mergeResultMsg{
-				success: false,
-				message: fmt.Sprintf("Merge unsuccessful: %s", resp.Message),
+// writeMessage writes the status message, if any
+func (m model) writeMessage(b *strings.Builder) {
+	if m.message != "" {
+		switch m.messageType {
+		case "error":
+			b.WriteString(errorStyle.Render(formatter.Symbols().Failed+" "+m.message) + "\n\n")
+		case "success":
+			b.WriteString(successStyle.Render(formatter.Symbols().Done+" "+m.message) + "\n\n")
+		default:
+			// refreshing中はこちら
+			if m.refreshing {
+				b.WriteString(dimStyle.Render(formatter.Symbols().Running+" "+m.message) + "\n\n")
+			} else {
+				b.WriteString(m.message + "\n\n")
 			}
 		}
-
-		return mergeResultMsg{
-			success: true,
-			message: fmt.Sprintf("Successfully merged PR #%d in %s", pr.Number, pr.Repository),
-		}
 	}
 }
 
-// rebasePR creates a command to trigger a rebase for the selected PR
-func (m *model) rebasePR(pr models.PullRequest) tea.Cmd {
-	return func() tea.Msg {
-		// Parse repository
-		owner, repo, err := api.ParseRepository(pr.Repository)
-		if err != nil {
-			return rebaseResultMsg{
-				success:    false,
-				message:    fmt.Sprintf("Invalid repository format: %v", err),
-				repository: pr.Repository,
-			}
-		}
+// formatPRLine formats a single PR line for display
+func (m model) formatPRLine(num int, pr models.PullRequest) string {
+	// Check if repository is being polled
+	pollingIcon := ""
+	if _, isPolling := m.pollingRepos[pr.Repository]; isPolling {
+		pollingIcon = icon.polling + " "
+	}
 
-		// Handle based on bot type
-		if pr.BotType.UsesCheckboxRebase() {
-			// Renovate: Update PR body to check the rebase checkbox
-			err := m.client.TriggerRenovateRebase(m.ctx, owner, repo, pr.Number, pr.Body)
-			if err != nil {
-				return rebaseResultMsg{
-					success:    false,
-					message:    fmt.Sprintf("Failed to trigger rebase: %v", err),
-					repository: pr.Repository,
-				}
-			}
+	// Show the operation in flight, if any
+	status := ""
+	if op, isBusy := m.operations[identify(pr)]; isBusy {
+		pollingIcon = icon.busy + " "
+		status = "[" + op.progress() + "...] "
+	}
 
-			return rebaseResultMsg{
-				success:    true,
-				message:    fmt.Sprintf("Rebase triggered for PR #%d in %s (checkbox checked)", pr.Number, pr.Repository),
-				repository: pr.Repository,
-			}
-		} else if pr.BotType.RebaseCommand() != "" {
-			// Dependabot: Post a comment
-			comment := pr.BotType.RebaseCommand()
-			_, err := m.client.CreateComment(m.ctx, owner, repo, pr.Number, comment)
-			if err != nil {
-				return rebaseResultMsg{
-					success:    false,
-					message:    fmt.Sprintf("Failed to post rebase comment: %v", err),
-					repository: pr.Repository,
-				}
-			}
+	// Columns are fitted to the terminal width by display width (see columnWidths)
+	return m.formatColumns([]string{
+		fmt.Sprintf("%s%d", pollingIcon, num),
+		pr.RepoName(),
+		pr.BotType.DisplayName(),
+		formatter.CheckStatus(pr.CheckSummary.Status),
+		formatter.MergeableState(pr.MergeableState),
+		formatLabels(pr.Labels),
+		pr.Version,
+		status + pr.Title,
+	})
+}
 
-			return rebaseResultMsg{
-				success:    true,
-				message:    fmt.Sprintf("Rebase triggered for PR #%d in %s (comment posted)", pr.Number, pr.Repository),
-				repository: pr.Repository,
-			}
-		}
+// filterPRs filters PRs based on query and the current tab
+// The query uses the filter expression language (see filter.Parse);
+// while the query is invalid, the previous results are kept.
+func (m *model) filterPRs() {
+	m.pruneSelection()
 
-		// This shouldn't happen as we check SupportsRebase before calling this
-		return rebaseResultMsg{
-			success:    false,
-			message:    fmt.Sprintf("Bot %s does not support rebase", pr.BotType.DisplayName()),
-			repository: pr.Repository,
+	f, err := filter.Parse(m.query)
+	if err != nil {
+		m.queryErr = err.Error()
+	} else {
+		m.queryErr = ""
+		if f.IsEmpty() {
+			m.matched = m.prs
+		} else {
+			m.matched = f.Apply(m.prs)
 		}
 	}
+
+	m.applyTab()
 }
 
 // refreshPRs creates a command to refresh all PRs from API
@@ -779,15 +815,8 @@ func (m *model) refreshPRs() tea.Cmd {
 	prevSelection := m.captureCurrentSelection()
 
 	return func() tea.Msg {
-		var prs []models.PullRequest
-		var err error
-
-		// Fetch PRs based on org or user
-		if m.isOrganization {
-			prs, err = m.client.FetchOrgPullRequests(m.ctx, m.target, m.limit)
-		} else {
-			prs, err = m.client.FetchUserPullRequests(m.ctx, m.target, m.limit)
-		}
+		// Fetch PRs with the same selection as the initial load
+		prs, err := m.fetcher.Fetch(m.ctx)
 
 		return refreshPRsMsg{
 			prs:           prs,
@@ -797,19 +826,6 @@ func (m *model) refreshPRs() tea.Cmd {
 	}
 }
 
-// mergeResultMsg represents the result of a merge operation
-type mergeResultMsg struct {
-	success bool
-	message string
-}
-
-// rebaseResultMsg represents the result of a rebase trigger operation
-type rebaseResultMsg struct {
-	success    bool
-	message    string
-	repository string // Repository that was rebased
-}
-
 // refreshPRsMsg represents the result of refreshing PRs
 type refreshPRsMsg struct {
 	prs           []models.PullRequest
@@ -831,35 +847,16 @@ type pollResultMsg struct {
 }
 
 // Helper functions
-func formatMergeableState(state models.MergeableState) string {
-	switch state {
-	case models.MergeableStateMergeable:
-		return "✓"
-	case models.MergeableStateConflicting:
-		return "✗"
-	case models.MergeableStateUnknown:
-		return "?"
-	default:
-		return "-"
-	}
-}
-
-func formatLabels(labels []string, maxLen int) string {
+func formatLabels(labels []string) string {
 	if len(labels) == 0 {
 		return "-"
 	}
-	joined := strings.Join(labels, ",")
-	return truncate(joined, maxLen)
+	return strings.Join(labels, ",")
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return s[:maxLen]
-	}
-	return s[:maxLen-3] + "..."
+// truncate truncates a string to a display width (wide characters count as two columns)
+func truncate(s string, maxWidth int) string {
+	return formatter.TruncateToWidth(s, maxWidth)
 }
 
 // openBrowser opens the given URL in the default browser
@@ -894,10 +891,10 @@ func (m *model) moveCursor(delta int) {
 	if m.cursor < 0 {
 		m.cursor = 0
 	}
-	if m.cursor >= len(m.filtered) {
-		m.cursor = len(m.filtered) - 1
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
 	}
-	if m.cursor < 0 && len(m.filtered) == 0 {
+	if m.cursor < 0 && len(m.rows) == 0 {
 		m.cursor = 0
 	}
 }
@@ -912,13 +909,11 @@ func (m *model) getPageSize() int {
 }
 
 // captureCurrentSelection saves the current cursor position as a PR identifier
+// (a repository header of the tree view has Number 0)
 func (m *model) captureCurrentSelection() *PRIdentifier {
-	if len(m.filtered) > 0 && m.cursor >= 0 && m.cursor < len(m.filtered) {
-		pr := m.filtered[m.cursor]
-		return &PRIdentifier{
-			Repository: pr.Repository,
-			Number:     pr.Number,
-		}
+	if row, ok := m.currentRow(); ok {
+		id := row.id()
+		return &id
 	}
 	return nil
 }
@@ -928,8 +923,8 @@ func (m *model) captureCurrentSelection() *PRIdentifier {
 func (m *model) restoreCursorPosition(prevSelection *PRIdentifier) {
 	if prevSelection == nil {
 		// No previous selection, keep current cursor or reset to 0
-		if m.cursor >= len(m.filtered) {
-			m.cursor = len(m.filtered) - 1
+		if m.cursor >= len(m.rows) {
+			m.cursor = len(m.rows) - 1
 		}
 		if m.cursor < 0 {
 			m.cursor = 0
@@ -938,16 +933,27 @@ func (m *model) restoreCursorPosition(prevSelection *PRIdentifier) {
 	}
 
 	// Try to find the same PR in new filtered list
-	for i, pr := range m.filtered {
-		if pr.Repository == prevSelection.Repository && pr.Number == prevSelection.Number {
+	for i, row := range m.rows {
+		if row.id() == *prevSelection {
 			m.cursor = i
 			return
 		}
 	}
 
+	// Fall back to the repository: its header when the PR is collapsed in the
+	// tree view, or its first PR when a header was selected
+	if m.tree || prevSelection.Number == 0 {
+		for i, row := range m.rows {
+			if row.repo == prevSelection.Repository {
+				m.cursor = i
+				return
+			}
+		}
+	}
+
 	// PR not found (merged/deleted), adjust cursor to valid position
-	if m.cursor >= len(m.filtered) {
-		m.cursor = len(m.filtered) - 1
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
 	}
 	if m.cursor < 0 {
 		m.cursor = 0
@@ -983,18 +989,8 @@ func (m *model) pollRepository(repository string) tea.Cmd {
 			}
 		}
 
-		// Parse repository
-		owner, repo, err := api.ParseRepository(repository)
-		if err != nil {
-			return pollResultMsg{
-				repository:  repository,
-				err:         err,
-				stopPolling: true,
-			}
-		}
-
 		// Fetch PRs for this repository
-		prs, err := m.client.FetchRepositoryPullRequests(m.ctx, owner, repo)
+		prs, err := m.fetcher.FetchRepository(m.ctx, repository)
 		if err != nil {
 			return pollResultMsg{
 				repository:  repository,
@@ -1080,9 +1076,7 @@ func (m *model) mergePolledPRs(newPRs []models.PullRequest) {
 	m.prs = filtered
 
 	// Re-sort
-	sort.Slice(m.prs, func(i, j int) bool {
-		return m.prs[i].RepoName() < m.prs[j].RepoName()
-	})
+	sorter.Sort(m.prs, m.sortKeys)
 
 	// Re-apply filter
 	m.filterPRs()
@@ -1091,22 +1085,47 @@ func (m *model) mergePolledPRs(newPRs []models.PullRequest) {
 	m.restoreCursorPosition(prevSelection)
 }
 
+// Options configures the interactive TUI
+type Options struct {
+	Fetcher     *api.Fetcher        // PR selection for refresh and polling (same as the initial load)
+	Verbose     bool                // Verbose mode
+	MergeMethod string              // Merge method (merge, squash, rebase)
+	Keymap      string              // Keymap preset adding vim or emacs keys ("" = default)
+	Theme       string              // Color theme (dark, light, high-contrast; "" = dark)
+	ASCII       bool                // Replace emoji and symbols with ASCII
+	Keybindings map[string][]string // Extra keys per action (see ValidateKeybindings)
+	SortKeys    []sorter.Key        // Initial sort keys (nil = sorter.DefaultKeys)
+	AuditLog    *audit.Log          // Audit log of merges, rebases and other actions (nil = disabled)
+}
+
 // RunTUI starts the interactive TUI
-func RunTUI(ctx context.Context, prs []models.PullRequest, client *api.Client, target string, isOrg bool, limit int, verbose bool) error {
+func RunTUI(ctx context.Context, prs []models.PullRequest, client *api.Client, opts Options) error {
+	// NO_COLOR disables colors when set to any non-empty value (https://no-color.org)
+	applyTheme(opts.Theme, os.Getenv("NO_COLOR") != "", opts.ASCII)
+
 	m := model{
-		prs:            prs,
-		filtered:       prs,
-		cursor:         0,
-		client:         client,
-		ctx:            ctx,
-		target:         target,
-		isOrganization: isOrg,
-		limit:          limit,
-		verbose:        verbose,
-		width:          80,
-		height:         24,
-		pollingRepos:   make(map[string]*pollState),
+		prs:          prs,
+		cursor:       0,
+		client:       client,
+		ctx:          ctx,
+		fetcher:      opts.Fetcher,
+		verbose:      opts.Verbose,
+		mergeMethod:  opts.MergeMethod,
+		keys:         newKeyMap(opts.Keymap, opts.Keybindings),
+		sortKeys:     opts.SortKeys,
+		width:        80,
+		height:       24,
+		pollingRepos: make(map[string]*pollState),
+		selected:     make(map[PRIdentifier]bool),
+		operations:   make(map[PRIdentifier]operation),
+		collapsed:    make(map[string]bool),
+		audit:        opts.AuditLog,
+	}
+
+	if len(m.sortKeys) == 0 {
+		m.sortKeys = sorter.DefaultKeys
 	}
+	m.filterPRs()
 
 	p := tea.NewProgram(m)
 	if _, err := p.Run(); err != nil {
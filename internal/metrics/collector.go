@@ -0,0 +1,214 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/health"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Content types of the exposition formats. Both are written identically;
+// Prometheus' text format treats the trailing "# EOF" as a comment.
+const (
+	contentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	contentTypeText        = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Collector holds the latest fetch results and exposes them as gauges
+type Collector struct {
+	mu            sync.RWMutex
+	prs           []models.PullRequest
+	attempted     bool // Whether a fetch has finished, successfully or not
+	fetched       bool // Whether a fetch has succeeded
+	fetchedAt     time.Time
+	fetchDuration time.Duration
+	fetchErr      error
+	rateLimit     *api.RateLimitInfo
+}
+
+// NewCollector creates an empty collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Update records the result of a fetch. On error the previous PRs are kept.
+func (c *Collector) Update(prs []models.PullRequest, fetchedAt time.Time, duration time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.attempted = true
+	c.fetchErr = err
+	c.fetchDuration = duration
+	if err == nil {
+		c.prs = prs
+		c.fetched = true
+		c.fetchedAt = fetchedAt
+	}
+}
+
+// UpdateRateLimit records the latest rate limit status
+func (c *Collector) UpdateRateLimit(info *api.RateLimitInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rateLimit = info
+}
+
+// ServeHTTP writes the metrics, negotiating OpenMetrics or the Prometheus text format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text") {
+		w.Header().Set("Content-Type", contentTypeOpenMetrics)
+	} else {
+		w.Header().Set("Content-Type", contentTypeText)
+	}
+
+	if err := c.Write(w, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Write writes all metrics in the OpenMetrics text format
+func (c *Collector) Write(w io.Writer, now time.Time) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e := &encoder{w: w}
+
+	// Until the first fetch finishes, report failure so that a hung fetch is not mistaken for a healthy one
+	success := 1.0
+	if !c.attempted || c.fetchErr != nil {
+		success = 0
+	}
+	e.gauge("gh_deps_fetch_success", "Whether the last fetch succeeded (1) or failed or has not finished yet (0).")
+	e.sample("gh_deps_fetch_success", nil, success)
+	if c.attempted {
+		e.gauge("gh_deps_fetch_duration_seconds", "Duration of the last fetch.")
+		e.sample("gh_deps_fetch_duration_seconds", nil, c.fetchDuration.Seconds())
+	}
+
+	if c.fetched {
+		e.gauge("gh_deps_last_success_timestamp_seconds", "Time of the last successful fetch.")
+		e.sample("gh_deps_last_success_timestamp_seconds", nil, float64(c.fetchedAt.Unix()))
+		c.writePullRequests(e, now)
+	}
+
+	if c.rateLimit != nil {
+		e.gauge("gh_deps_rate_limit_limit", "GitHub GraphQL API rate limit.")
+		e.sample("gh_deps_rate_limit_limit", nil, float64(c.rateLimit.Limit))
+		e.gauge("gh_deps_rate_limit_remaining", "Remaining GitHub GraphQL API rate limit.")
+		e.sample("gh_deps_rate_limit_remaining", nil, float64(c.rateLimit.Remaining))
+		e.gauge("gh_deps_rate_limit_reset_timestamp_seconds", "Time the GitHub GraphQL API rate limit resets.")
+		e.sample("gh_deps_rate_limit_reset_timestamp_seconds", nil, float64(c.rateLimit.ResetAt.Unix()))
+	}
+
+	e.line("# EOF")
+	return e.err
+}
+
+// prGroup identifies a set of PRs counted together
+type prGroup struct {
+	repository string
+	bot        string
+	ci         string
+	mergeable  string
+}
+
+// writePullRequests writes the PR gauges
+func (c *Collector) writePullRequests(e *encoder, now time.Time) {
+	counts := make(map[prGroup]int)
+	for _, pr := range c.prs {
+		counts[prGroup{
+			repository: pr.Repository,
+			bot:        string(pr.BotType),
+			ci:         pr.CheckSummary.Status.Name(),
+			mergeable:  strings.ToLower(string(pr.MergeableState)),
+		}]++
+	}
+
+	groups := make([]prGroup, 0, len(counts))
+	for g := range counts {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.repository != b.repository {
+			return a.repository < b.repository
+		}
+		if a.bot != b.bot {
+			return a.bot < b.bot
+		}
+		if a.ci != b.ci {
+			return a.ci < b.ci
+		}
+		return a.mergeable < b.mergeable
+	})
+
+	e.gauge("gh_deps_open_pull_requests", "Open dependency update PRs.")
+	for _, g := range groups {
+		e.sample("gh_deps_open_pull_requests", []string{
+			"repository", g.repository, "bot", g.bot, "ci", g.ci, "mergeable", g.mergeable,
+		}, float64(counts[g]))
+	}
+
+	repos := health.Compute(c.prs, now)
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Repository < repos[j].Repository })
+
+	e.gauge("gh_deps_oldest_pull_request_age_seconds", "Age of the oldest open dependency update PR.")
+	for _, h := range repos {
+		e.sample("gh_deps_oldest_pull_request_age_seconds", []string{"repository", h.Repository}, h.OldestAge.Seconds())
+	}
+
+	e.gauge("gh_deps_repository_health_score", "Dependency health score of the repository (0-100).")
+	for _, h := range repos {
+		e.sample("gh_deps_repository_health_score", []string{"repository", h.Repository}, float64(h.Score))
+	}
+}
+
+// encoder writes metric lines and remembers the first write error
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+// gauge writes the metadata of a gauge metric family
+func (e *encoder) gauge(name, help string) {
+	e.line("# TYPE " + name + " gauge")
+	e.line("# HELP " + name + " " + help)
+}
+
+// sample writes a sample with label name/value pairs
+func (e *encoder) sample(name string, labels []string, value float64) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteString("{")
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(&b, `%s="%s"`, labels[i], escapeLabelValue(labels[i+1]))
+		}
+		b.WriteString("}")
+	}
+	fmt.Fprintf(&b, " %g", value)
+	e.line(b.String())
+}
+
+// line writes a single line
+func (e *encoder) line(s string) {
+	if e.err == nil {
+		_, e.err = io.WriteString(e.w, s+"\n")
+	}
+}
+
+// escapeLabelValue escapes backslashes, double quotes and newlines in label values
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -1,6 +1,9 @@
 package models
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // BotType represents the type of dependency update bot
 type BotType string
@@ -34,6 +37,15 @@ func DetectBot(author string) (BotType, bool) {
 	return "", false
 }
 
+// ParseBotType parses a bot type name (renovate, dependabot, github-actions)
+func ParseBotType(name string) (BotType, error) {
+	botType := BotType(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := BotLogins[botType]; !ok {
+		return "", fmt.Errorf("unknown bot type: %s (expected renovate, dependabot, or github-actions)", name)
+	}
+	return botType, nil
+}
+
 // DisplayName returns a clean display name for the bot (removes [bot] suffix)
 func (b BotType) DisplayName() string {
 	return string(b)
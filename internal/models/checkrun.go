@@ -12,11 +12,26 @@ const (
 	StatusNone    CheckStatus = "-"
 )
 
+// Name returns a plain-text name for the status (success, failure, pending, none)
+func (s CheckStatus) Name() string {
+	switch s {
+	case StatusSuccess:
+		return "success"
+	case StatusFailure:
+		return "failure"
+	case StatusPending:
+		return "pending"
+	default:
+		return "none"
+	}
+}
+
 // CheckRun represents a single check run from GitHub
 type CheckRun struct {
 	Name       string
 	Status     string // queued, in_progress, completed
 	Conclusion string // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	URL        string // Details page of the check
 }
 
 // CheckSummary aggregates check run results
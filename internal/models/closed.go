@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ClosedPullRequest represents a dependency update PR that was merged or closed
+type ClosedPullRequest struct {
+	Repository string    // Full repository name (owner/repo)
+	Number     int       // PR number
+	Title      string    // PR title
+	BotType    BotType   // Detected bot type
+	CreatedAt  time.Time // Creation timestamp
+	ClosedAt   time.Time // Merge or close timestamp
+	Merged     bool      // Whether the PR was merged (false = closed without merging)
+}
+
+// Lifetime returns how long the PR was open
+func (pr *ClosedPullRequest) Lifetime() time.Duration {
+	return pr.ClosedAt.Sub(pr.CreatedAt)
+}
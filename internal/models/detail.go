@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// PullRequestDetail holds the PR information shown in the interactive detail view.
+// It is fetched on demand for a single PR.
+type PullRequestDetail struct {
+	Checks        []CheckRun    // Individual check runs and commit statuses of the head commit
+	Files         []ChangedFile // Changed files (first page)
+	ChangedFiles  int           // Total number of changed files
+	Reviews       []Review      // Latest reviews
+	Comments      []Comment     // Latest comments
+	TotalComments int           // Total number of comments
+}
+
+// ChangedFile represents a file changed by a PR
+type ChangedFile struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// Review represents a PR review
+type Review struct {
+	Author      string
+	State       string // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
+	Body        string
+	SubmittedAt time.Time
+}
+
+// Comment represents a PR conversation comment
+type Comment struct {
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
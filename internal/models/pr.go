@@ -14,6 +14,15 @@ const (
 	MergeableStateUnknown     MergeableState = "UNKNOWN"     // State is being calculated
 )
 
+// ReviewDecision represents the review decision of a PR
+type ReviewDecision string
+
+const (
+	ReviewDecisionApproved         ReviewDecision = "APPROVED"          // Approved by required reviewers
+	ReviewDecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED" // Changes were requested
+	ReviewDecisionReviewRequired   ReviewDecision = "REVIEW_REQUIRED"   // Review is required but not given
+)
+
 // PullRequest represents a dependency update pull request
 type PullRequest struct {
 	Repository     string         // Full repository name (owner/repo)
@@ -24,9 +33,16 @@ type PullRequest struct {
 	CreatedAt      time.Time      // Creation timestamp
 	URL            string         // PR URL
 	HeadSHA        string         // Head commit SHA
+	HeadRef        string         // Head branch name
+	BaseRef        string         // Base branch name
+	Additions      int            // Lines added
+	Deletions      int            // Lines deleted
+	ReviewDecision ReviewDecision // Review decision (APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED)
 	BotType        BotType        // Detected bot type
 	CheckSummary   CheckSummary   // Aggregated check status
 	Version        string         // Extracted version info (e.g., "1.0.0 -> 1.1.0")
+	Dependency     string         // Extracted dependency name (e.g., "express")
+	UpdateType     UpdateType     // Classified version change (major, minor, patch, ...)
 	MergeableState MergeableState // Mergeable state (MERGEABLE, CONFLICTING, UNKNOWN)
 	Labels         []string       // PR labels
 }
@@ -36,6 +52,11 @@ func (pr *PullRequest) FormattedDate() string {
 	return pr.CreatedAt.Format("2006-01-02")
 }
 
+// Age returns the time elapsed since the PR was created
+func (pr *PullRequest) Age(now time.Time) time.Duration {
+	return now.Sub(pr.CreatedAt)
+}
+
 // RepoName extracts just the repository name from the full name (owner/repo)
 func (pr *PullRequest) RepoName() string {
 	parts := strings.Split(pr.Repository, "/")
@@ -44,3 +65,26 @@ func (pr *PullRequest) RepoName() string {
 	}
 	return pr.Repository
 }
+
+// dependabotSecurityNote appears in the "Dependabot commands and options"
+// section of the body only on Dependabot security update PRs
+const dependabotSecurityNote = "automated security fix prs"
+
+// IsSecurityUpdate reports whether the PR fixes a security vulnerability.
+// Renovate marks vulnerability fixes with "[SECURITY]" in the title, Dependabot
+// security updates are recognized by their body, and both bots can be
+// configured to add a "security" label.
+func (pr *PullRequest) IsSecurityUpdate() bool {
+	if strings.Contains(strings.ToLower(pr.Title), "[security]") {
+		return true
+	}
+	if pr.BotType == BotDependabot && strings.Contains(strings.ToLower(pr.Body), dependabotSecurityNote) {
+		return true
+	}
+	for _, label := range pr.Labels {
+		if strings.Contains(strings.ToLower(label), "security") {
+			return true
+		}
+	}
+	return false
+}
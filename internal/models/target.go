@@ -0,0 +1,33 @@
+package models
+
+// Target represents an organization, team or user whose repositories are scanned
+type Target struct {
+	Name           string // Organization or user name
+	IsOrganization bool   // True if targeting an organization, false for user
+	Team           string // Team slug within the organization (empty for whole org)
+}
+
+// Kind returns "organization", "team" or "user" depending on the target type
+func (t Target) Kind() string {
+	switch {
+	case t.Team != "":
+		return "team"
+	case t.IsOrganization:
+		return "organization"
+	default:
+		return "user"
+	}
+}
+
+// DisplayName returns the target name, including the team slug for teams (org/team)
+func (t Target) DisplayName() string {
+	if t.Team != "" {
+		return t.Name + "/" + t.Team
+	}
+	return t.Name
+}
+
+// String returns the target in "kind:name" form (e.g., "organization:my-org")
+func (t Target) String() string {
+	return t.Kind() + ":" + t.DisplayName()
+}
@@ -0,0 +1,29 @@
+package models
+
+// UpdateType represents the semantic version change of a dependency update
+type UpdateType string
+
+const (
+	UpdateMajor   UpdateType = "major"   // X.y.z changed
+	UpdateMinor   UpdateType = "minor"   // x.Y.z changed
+	UpdatePatch   UpdateType = "patch"   // x.y.Z changed
+	UpdateDigest  UpdateType = "digest"  // Commit digest or pin change
+	UpdateUnknown UpdateType = "unknown" // Version could not be classified
+)
+
+// Rank returns the severity order of the update type (patch < minor < major)
+// Digest and unknown updates rank below patch updates
+func (u UpdateType) Rank() int {
+	switch u {
+	case UpdateMajor:
+		return 4
+	case UpdateMinor:
+		return 3
+	case UpdatePatch:
+		return 2
+	case UpdateDigest:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/diff"
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// EventType identifies what happened to a PR
+type EventType string
+
+const (
+	EventNewPR       EventType = "new_pr"      // A new dependency PR appeared
+	EventCIFailed    EventType = "ci_failed"   // CI of a PR went red
+	EventConflicting EventType = "conflicting" // A PR became conflicting
+)
+
+// EventTypes lists all event types
+var EventTypes = []EventType{EventNewPR, EventCIFailed, EventConflicting}
+
+// Event is a notification about a single PR
+type Event struct {
+	Type        EventType
+	PullRequest models.PullRequest
+}
+
+// Summary returns a one-line description of the event
+func (e Event) Summary() string {
+	pr := e.PullRequest
+	var what string
+	switch e.Type {
+	case EventNewPR:
+		what = "New PR"
+	case EventCIFailed:
+		what = "CI failed"
+	case EventConflicting:
+		what = "Conflicting"
+	}
+	return fmt.Sprintf("%s: %s#%d %s (%s)", what, pr.Repository, pr.Number, pr.Title, pr.BotType.DisplayName())
+}
+
+// ParseEventTypes parses a comma-separated list of event types
+func ParseEventTypes(spec string) ([]EventType, error) {
+	var types []EventType
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, t := range EventTypes {
+			if string(t) == name {
+				types = append(types, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown notification event: %s (expected new_pr, ci_failed, or conflicting)", name)
+		}
+	}
+	return types, nil
+}
+
+// EventsFromChanges builds notification events from the changes between two fetches.
+// Only the given event types are returned (all when types is empty).
+func EventsFromChanges(changes []diff.Change, types []EventType) []Event {
+	wanted := func(t EventType) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, w := range types {
+			if w == t {
+				return true
+			}
+		}
+		return false
+	}
+
+	var events []Event
+	for _, change := range changes {
+		pr := change.PR
+		switch change.Kind {
+		case diff.KindAdded:
+			if wanted(EventNewPR) {
+				events = append(events, Event{Type: EventNewPR, PullRequest: pr})
+			}
+		case diff.KindChanged:
+			if change.Has(diff.FieldCI) && pr.CheckSummary.Status == models.StatusFailure && wanted(EventCIFailed) {
+				events = append(events, Event{Type: EventCIFailed, PullRequest: pr})
+			}
+			if change.Has(diff.FieldMergeable) && pr.MergeableState == models.MergeableStateConflicting && wanted(EventConflicting) {
+				events = append(events, Event{Type: EventConflicting, PullRequest: pr})
+			}
+		}
+	}
+	return events
+}
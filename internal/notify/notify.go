@@ -0,0 +1,199 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// webhookTimeout bounds each webhook request
+const webhookTimeout = 10 * time.Second
+
+// Notifier delivers notification events
+type Notifier interface {
+	// Notify delivers the events. It is not called with an empty list.
+	Notify(ctx context.Context, events []Event) error
+	// String describes the notifier for messages (e.g., "slack")
+	String() string
+}
+
+// Parse creates a notifier from a spec:
+//   - "webhook=URL" posts the events as JSON
+//   - "slack=URL" posts a Slack-compatible incoming-webhook message
+//   - "bell" rings the terminal bell
+func Parse(spec string, w io.Writer) (Notifier, error) {
+	kind, target, _ := strings.Cut(strings.TrimSpace(spec), "=")
+	switch kind {
+	case "bell":
+		return &BellNotifier{w: w}, nil
+	case "webhook", "slack":
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid %s URL: %q", kind, target)
+		}
+		client := &http.Client{Timeout: webhookTimeout}
+		if kind == "slack" {
+			return &SlackNotifier{url: target, client: client}, nil
+		}
+		return &WebhookNotifier{url: target, client: client}, nil
+	default:
+		return nil, fmt.Errorf("invalid notifier: %s (expected webhook=URL, slack=URL, or bell)", spec)
+	}
+}
+
+// Multi delivers events to several notifiers
+type Multi []Notifier
+
+// Notify delivers the events to every notifier and joins their errors
+func (m Multi) Notify(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, events); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BellNotifier rings the terminal bell
+type BellNotifier struct {
+	w io.Writer
+}
+
+// Notify writes a BEL character
+func (n *BellNotifier) Notify(_ context.Context, _ []Event) error {
+	_, err := io.WriteString(n.w, "\a")
+	return err
+}
+
+func (n *BellNotifier) String() string {
+	return "bell"
+}
+
+// webhookEvent is the JSON representation of an event for generic webhooks
+type webhookEvent struct {
+	Type       EventType `json:"type"`
+	Summary    string    `json:"summary"`
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	Bot        string    `json:"bot"`
+	CI         string    `json:"ci"`
+	Mergeable  string    `json:"mergeable"`
+	Version    string    `json:"version"`
+	Dependency string    `json:"dependency"`
+	UpdateType string    `json:"update_type"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookNotifier posts events as JSON ({"events": [...]}) to a URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify posts the events
+func (n *WebhookNotifier) Notify(ctx context.Context, events []Event) error {
+	payload := struct {
+		Events []webhookEvent `json:"events"`
+	}{Events: make([]webhookEvent, 0, len(events))}
+
+	for _, e := range events {
+		pr := e.PullRequest
+		payload.Events = append(payload.Events, webhookEvent{
+			Type:       e.Type,
+			Summary:    e.Summary(),
+			Repository: pr.Repository,
+			Number:     pr.Number,
+			Title:      pr.Title,
+			URL:        pr.URL,
+			Bot:        string(pr.BotType),
+			CI:         pr.CheckSummary.Status.Name(),
+			Mergeable:  string(pr.MergeableState),
+			Version:    pr.Version,
+			Dependency: pr.Dependency,
+			UpdateType: string(pr.UpdateType),
+			CreatedAt:  pr.CreatedAt,
+		})
+	}
+
+	return postJSON(ctx, n.client, n.url, payload)
+}
+
+func (n *WebhookNotifier) String() string {
+	return "webhook"
+}
+
+// SlackNotifier posts events as a Slack-compatible incoming-webhook message
+type SlackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify posts a single message listing all events
+func (n *SlackNotifier) Notify(ctx context.Context, events []Event) error {
+	lines := make([]string, 0, len(events)+1)
+	lines = append(lines, fmt.Sprintf("*gh-deps*: %d dependency PR update(s)", len(events)))
+	for _, e := range events {
+		pr := e.PullRequest
+		var icon string
+		switch e.Type {
+		case EventNewPR:
+			icon = ":new:"
+		case EventCIFailed:
+			icon = ":x:"
+		case EventConflicting:
+			icon = ":warning:"
+		}
+		lines = append(lines, fmt.Sprintf("%s <%s|%s#%d> %s (%s)",
+			icon, pr.URL, pr.Repository, pr.Number, escapeSlack(pr.Title), pr.BotType.DisplayName()))
+	}
+
+	return postJSON(ctx, n.client, n.url, map[string]string{"text": strings.Join(lines, "\n")})
+}
+
+func (n *SlackNotifier) String() string {
+	return "slack"
+}
+
+// escapeSlack escapes the characters Slack treats as control sequences
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// postJSON posts a JSON payload and fails on non-2xx responses
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+var (
+	// Conventional commit prefix: "chore(deps): ", "build(deps-dev): ", "fix: "
+	commitPrefixRegex = regexp.MustCompile(`^\w+(?:\([^)]*\))?!?:\s*`)
+
+	// Dependabot format: "Bump express from 1.0.0 to 1.1.0", "Bump the npm group across 1 directory"
+	dependabotTitleRegex = regexp.MustCompile(`(?i)^bump\s+(?:the\s+)?(\S+)`)
+
+	// Renovate format: "Update dependency express to v1.3.0", "Update actions/checkout action to v4",
+	// "Update module github.com/foo/bar to v2", "Update node.js to v20"
+	renovateTitleRegex = regexp.MustCompile(`(?i)^update\s+(?:dependency\s+|module\s+|plugin\s+)?(\S+)`)
+)
+
+// ExtractDependency extracts the dependency name from a PR title based on bot type
+// Returns "-" if not found
+func ExtractDependency(title string, botType models.BotType) string {
+	title = commitPrefixRegex.ReplaceAllString(strings.TrimSpace(title), "")
+
+	var regex *regexp.Regexp
+	switch botType {
+	case models.BotDependabot:
+		regex = dependabotTitleRegex
+	case models.BotRenovate, models.BotGitHubActions:
+		regex = renovateTitleRegex
+	default:
+		return "-"
+	}
+
+	matches := regex.FindStringSubmatch(title)
+	if len(matches) < 2 {
+		return "-"
+	}
+
+	return strings.Trim(matches[1], "`'\"")
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+var (
+	// Numeric version core, e.g. "1.2.3" in "v1.2.3-beta" or "^1.2"
+	versionCoreRegex = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+	// Commit digests and SHA pins, e.g. "abc1234" or "sha256:..."
+	digestRegex = regexp.MustCompile(`^(?:sha256:)?[0-9a-f]{7,64}$`)
+)
+
+// ClassifyUpdate classifies a version change formatted as "X -> Y" (see ExtractVersion)
+// into a major, minor, patch or digest update
+func ClassifyUpdate(version string) models.UpdateType {
+	from, to, ok := strings.Cut(version, " -> ")
+	if !ok {
+		return models.UpdateUnknown
+	}
+	from = strings.TrimSpace(from)
+	to = strings.TrimSpace(to)
+
+	if isDigest(from) || isDigest(to) {
+		return models.UpdateDigest
+	}
+
+	fromParts := versionCoreRegex.FindStringSubmatch(from)
+	toParts := versionCoreRegex.FindStringSubmatch(to)
+	if fromParts == nil || toParts == nil {
+		return models.UpdateUnknown
+	}
+
+	// Compare major, minor and patch components in order
+	types := []models.UpdateType{models.UpdateMajor, models.UpdateMinor, models.UpdatePatch}
+	for i, updateType := range types {
+		if trimZeros(fromParts[i+1]) != trimZeros(toParts[i+1]) {
+			return updateType
+		}
+	}
+
+	// Same core version (e.g., pre-release or build metadata change)
+	return models.UpdatePatch
+}
+
+// isDigest reports whether a version is a commit digest rather than a number
+// (purely numeric strings such as date versions are not digests)
+func isDigest(version string) bool {
+	return digestRegex.MatchString(version) && strings.ContainsAny(version, "abcdef")
+}
+
+// trimZeros normalizes a numeric version component ("" and "0" are equal)
+func trimZeros(s string) string {
+	s = strings.TrimLeft(s, "0")
+	if s == "" {
+		return "0"
+	}
+	return s
+}
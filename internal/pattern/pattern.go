@@ -0,0 +1,99 @@
+package pattern
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// regexPrefix marks a pattern as a regular expression instead of a glob
+const regexPrefix = "re:"
+
+// Pattern matches names against a glob (e.g., "svc-*") or a regular
+// expression written with the "re:" prefix (e.g., "re:^svc-")
+type Pattern struct {
+	raw   string
+	regex *regexp.Regexp // Set for "re:" patterns
+}
+
+// Compile parses a glob or "re:" regular expression pattern
+func Compile(s string) (*Pattern, error) {
+	if expr, ok := strings.CutPrefix(s, regexPrefix); ok {
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", expr, err)
+		}
+		return &Pattern{raw: s, regex: regex}, nil
+	}
+
+	// Validate glob syntax up front so that Match never fails
+	if _, err := path.Match(s, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", s, err)
+	}
+	return &Pattern{raw: s}, nil
+}
+
+// Match reports whether name matches the pattern.
+// Globs must match the whole name case-insensitively (like GitHub repository
+// names), while regular expressions may match any part of it.
+func (p *Pattern) Match(name string) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(name)
+	}
+	matched, _ := path.Match(strings.ToLower(p.raw), strings.ToLower(name))
+	return matched
+}
+
+// MatchRepository reports whether a repository (owner/name) matches the pattern.
+// Globs containing "/" are matched against the full name and other globs against
+// the repository name only, so "legacy-*" matches repositories of every owner.
+// Regular expressions match if either the full name or the name matches.
+func (p *Pattern) MatchRepository(nameWithOwner string) bool {
+	name := nameWithOwner
+	if i := strings.LastIndex(nameWithOwner, "/"); i >= 0 {
+		name = nameWithOwner[i+1:]
+	}
+
+	if p.regex != nil {
+		return p.regex.MatchString(nameWithOwner) || p.regex.MatchString(name)
+	}
+	if strings.Contains(p.raw, "/") {
+		return p.Match(nameWithOwner)
+	}
+	return p.Match(name)
+}
+
+// IsPattern reports whether s is a "re:" regex or contains glob metacharacters,
+// as opposed to a literal name
+func IsPattern(s string) bool {
+	return strings.HasPrefix(s, regexPrefix) || strings.ContainsAny(s, "*?[")
+}
+
+// CompileAll compiles a list of patterns
+func CompileAll(list []string) ([]*Pattern, error) {
+	patterns := make([]*Pattern, 0, len(list))
+	for _, s := range list {
+		p, err := Compile(s)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// MatchAnyRepository reports whether the repository matches any of the patterns
+func MatchAnyRepository(patterns []*Pattern, nameWithOwner string) bool {
+	for _, p := range patterns {
+		if p.MatchRepository(nameWithOwner) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the pattern as written
+func (p *Pattern) String() string {
+	return p.raw
+}
@@ -0,0 +1,275 @@
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/api"
+	"github.com/swfz/gh-deps/internal/filter"
+	"github.com/swfz/gh-deps/internal/models"
+	"github.com/swfz/gh-deps/internal/pattern"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule declares which PRs may be merged unattended.
+// Empty selectors match every PR; all selectors and conditions must hold.
+type Rule struct {
+	Name         string   `yaml:"name"`         // Rule name shown in decisions
+	Bots         []string `yaml:"bots"`         // Bot types (renovate, dependabot, github-actions)
+	UpdateTypes  []string `yaml:"update_types"` // Update types (major, minor, patch, digest, unknown)
+	Dependencies []string `yaml:"dependencies"` // Dependency name globs or "re:" regexes
+	Repos        []string `yaml:"repos"`        // Repository globs or "re:" regexes
+	RequireCI    *bool    `yaml:"require_ci"`   // Require successful CI (default: true)
+	MinAge       string   `yaml:"min_age"`      // Minimum PR age (e.g., 3d)
+	Labels       []string `yaml:"labels"`       // Labels that must all be present
+	MergeMethod  string   `yaml:"merge_method"` // Merge method (default: --merge-method)
+
+	bots         []models.BotType
+	updateTypes  []models.UpdateType
+	dependencies []*pattern.Pattern
+	repos        []*pattern.Pattern
+	minAge       time.Duration
+}
+
+// Policy is an ordered list of automerge rules
+type Policy struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Load reads and validates a policy file.
+// defaultMergeMethod is used by rules without a merge_method.
+func Load(path, defaultMergeMethod string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("policy file not found: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %w", path, err)
+	}
+
+	// Unknown keys are rejected: a misspelled condition would silently loosen a rule
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+	if len(p.Rules) == 0 {
+		return nil, fmt.Errorf("policy file %s has no rules", path)
+	}
+
+	for i, rule := range p.Rules {
+		if rule == nil {
+			return nil, fmt.Errorf("policy file %s: rule %d is empty", path, i+1)
+		}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if err := rule.compile(defaultMergeMethod); err != nil {
+			return nil, fmt.Errorf("policy file %s: %s: %w", path, rule.Name, err)
+		}
+	}
+	return &p, nil
+}
+
+// compile validates the rule and prepares its matchers
+func (r *Rule) compile(defaultMergeMethod string) error {
+	for _, name := range r.Bots {
+		bot, err := models.ParseBotType(name)
+		if err != nil {
+			return err
+		}
+		r.bots = append(r.bots, bot)
+	}
+
+	for _, name := range r.UpdateTypes {
+		updateType := models.UpdateType(strings.ToLower(name))
+		if updateType.Rank() == 0 && updateType != models.UpdateUnknown {
+			return fmt.Errorf("invalid update type: %s (expected major, minor, patch, digest, or unknown)", name)
+		}
+		r.updateTypes = append(r.updateTypes, updateType)
+	}
+
+	var err error
+	if r.dependencies, err = pattern.CompileAll(r.Dependencies); err != nil {
+		return fmt.Errorf("invalid dependency pattern: %w", err)
+	}
+	if r.repos, err = pattern.CompileAll(r.Repos); err != nil {
+		return fmt.Errorf("invalid repository pattern: %w", err)
+	}
+
+	if r.MinAge != "" {
+		if r.minAge, err = filter.ParseAge(r.MinAge); err != nil {
+			return fmt.Errorf("invalid min_age: %w", err)
+		}
+	}
+
+	if r.MergeMethod == "" {
+		r.MergeMethod = defaultMergeMethod
+	}
+	return api.ValidateMergeMethod(r.MergeMethod)
+}
+
+// requiresCI reports whether the rule requires successful CI
+func (r *Rule) requiresCI() bool {
+	return r.RequireCI == nil || *r.RequireCI
+}
+
+// selects reports whether the PR is in the scope of the rule
+func (r *Rule) selects(pr *models.PullRequest) bool {
+	if len(r.bots) > 0 && !containsBot(r.bots, pr.BotType) {
+		return false
+	}
+	if len(r.updateTypes) > 0 && !containsUpdateType(r.updateTypes, pr.UpdateType) {
+		return false
+	}
+	if len(r.dependencies) > 0 && !matchAny(r.dependencies, pr.Dependency) {
+		return false
+	}
+	if len(r.repos) > 0 && !pattern.MatchAnyRepository(r.repos, pr.Repository) {
+		return false
+	}
+	return true
+}
+
+// check returns why the PR may not be merged yet under the rule, or "" when it may
+func (r *Rule) check(pr *models.PullRequest, now time.Time) string {
+	if r.requiresCI() && pr.CheckSummary.Status != models.StatusSuccess {
+		return fmt.Sprintf("CI is %s", pr.CheckSummary.Status.Name())
+	}
+	if age := pr.Age(now); age < r.minAge {
+		return fmt.Sprintf("younger than %s", r.MinAge)
+	}
+	for _, label := range r.Labels {
+		if !hasLabel(pr.Labels, label) {
+			return fmt.Sprintf("missing label %q", label)
+		}
+	}
+	return ""
+}
+
+// Action is the outcome of evaluating a PR
+type Action string
+
+const (
+	ActionMerge Action = "merge" // Every condition of a rule holds
+	ActionSkip  Action = "skip"  // No rule allows merging the PR
+)
+
+// Decision is the result of evaluating a PR against the policy
+type Decision struct {
+	PR          models.PullRequest
+	Action      Action
+	Rule        string // Name of the deciding rule (empty when no rule selects the PR)
+	MergeMethod string // Merge method of the rule (merge only)
+	Reason      string // Why the PR is merged or skipped
+}
+
+// Evaluate decides whether each PR may be merged.
+// The first rule whose selectors and conditions all hold merges the PR. Otherwise
+// the PR is skipped with the reason from the first rule that selected it.
+func (p *Policy) Evaluate(prs []models.PullRequest, now time.Time) []Decision {
+	decisions := make([]Decision, 0, len(prs))
+	for _, pr := range prs {
+		decisions = append(decisions, p.evaluate(pr, now))
+	}
+	return decisions
+}
+
+func (p *Policy) evaluate(pr models.PullRequest, now time.Time) Decision {
+	// Conditions that block merging regardless of the rules
+	switch {
+	case pr.MergeableState == models.MergeableStateConflicting:
+		return Decision{PR: pr, Action: ActionSkip, Reason: "has conflicts"}
+	case pr.MergeableState != models.MergeableStateMergeable:
+		return Decision{PR: pr, Action: ActionSkip, Reason: "mergeability is unknown"}
+	case pr.ReviewDecision == models.ReviewDecisionChangesRequested:
+		return Decision{PR: pr, Action: ActionSkip, Reason: "changes were requested"}
+	}
+
+	var skip *Decision
+	for _, rule := range p.Rules {
+		if !rule.selects(&pr) {
+			continue
+		}
+		if reason := rule.check(&pr, now); reason != "" {
+			if skip == nil {
+				skip = &Decision{PR: pr, Action: ActionSkip, Rule: rule.Name, Reason: reason}
+			}
+			continue
+		}
+		return Decision{
+			PR:          pr,
+			Action:      ActionMerge,
+			Rule:        rule.Name,
+			MergeMethod: rule.MergeMethod,
+			Reason:      rule.describe(),
+		}
+	}
+
+	if skip != nil {
+		return *skip
+	}
+	return Decision{PR: pr, Action: ActionSkip, Reason: "no rule matches"}
+}
+
+// describe summarizes the conditions of the rule for merge decisions
+func (r *Rule) describe() string {
+	var conditions []string
+	if r.requiresCI() {
+		conditions = append(conditions, "CI passed")
+	}
+	if r.minAge > 0 {
+		conditions = append(conditions, "older than "+r.MinAge)
+	}
+	if len(r.Labels) > 0 {
+		conditions = append(conditions, "labeled "+strings.Join(r.Labels, ", "))
+	}
+	if len(conditions) == 0 {
+		return "no conditions required"
+	}
+	return strings.Join(conditions, ", ")
+}
+
+func containsBot(bots []models.BotType, bot models.BotType) bool {
+	for _, b := range bots {
+		if b == bot {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUpdateType(types []models.UpdateType, updateType models.UpdateType) bool {
+	for _, t := range types {
+		if t == updateType {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAny reports whether name matches any of the patterns
+func matchAny(patterns []*pattern.Pattern, name string) bool {
+	for _, p := range patterns {
+		if p.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLabel reports whether labels contain the label (case-insensitive, like GitHub)
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+const (
+	stateDirName     = "gh-deps"
+	defaultStateBase = ".local/state"
+	snapshotsDirName = "snapshots"
+
+	// fileTimeFormat names snapshot files so that they sort chronologically
+	fileTimeFormat = "20060102T150405.000000000Z"
+
+	// KeepAllPeriod is how long every snapshot is kept
+	KeepAllPeriod = 24 * time.Hour
+
+	// ThinInterval is the spacing of the snapshots kept after KeepAllPeriod
+	ThinInterval = time.Hour
+
+	// MaxAge is how long snapshots are kept at all
+	MaxAge = 30 * 24 * time.Hour
+)
+
+// unsafeNameChars matches characters replaced in scope directory names
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Snapshot is the result of a single fetch
+type Snapshot struct {
+	Scope        string               `json:"scope"`      // Selection the PRs were fetched with
+	CreatedAt    time.Time            `json:"created_at"` // Fetch time
+	PullRequests []models.PullRequest `json:"pull_requests"`
+}
+
+// Store saves snapshots of one scope as JSON files in a directory
+type Store struct {
+	dir   string
+	scope string
+}
+
+// StateDir returns the gh-deps state directory:
+// $XDG_STATE_HOME/gh-deps, or ~/.local/state/gh-deps
+func StateDir() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine state directory: %w", err)
+		}
+		stateHome = filepath.Join(home, defaultStateBase)
+	}
+	return filepath.Join(stateHome, stateDirName), nil
+}
+
+// Open returns the store for a scope under the state directory.
+// The scope describes the PR selection (targets, repositories, filters) so that
+// snapshots of different selections are never compared with each other.
+func Open(stateDir, scope string) *Store {
+	return &Store{
+		dir:   filepath.Join(stateDir, snapshotsDirName, scopeDirName(scope)),
+		scope: scope,
+	}
+}
+
+// Dir returns the directory holding the snapshots
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save writes a snapshot of the PRs and prunes old snapshots relative to createdAt
+func (s *Store) Save(prs []models.PullRequest, createdAt time.Time) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	if prs == nil {
+		prs = []models.PullRequest{}
+	}
+	data, err := json.Marshal(Snapshot{Scope: s.scope, CreatedAt: createdAt.UTC(), PullRequests: prs})
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	path := filepath.Join(s.dir, createdAt.UTC().Format(fileTimeFormat)+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return s.prune(createdAt)
+}
+
+// Latest returns the most recent snapshot, or nil if there is none
+func (s *Store) Latest() (*Snapshot, error) {
+	files, err := s.files()
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	return s.load(files[len(files)-1])
+}
+
+// Before returns the most recent snapshot taken at or before t, or nil if there is none
+func (s *Store) Before(t time.Time) (*Snapshot, error) {
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := t.UTC().Format(fileTimeFormat) + ".json"
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i] <= cutoff {
+			return s.load(files[i])
+		}
+	}
+	return nil, nil
+}
+
+// Oldest returns the oldest snapshot, or nil if there is none
+func (s *Store) Oldest() (*Snapshot, error) {
+	files, err := s.files()
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	return s.load(files[0])
+}
+
+// files returns the snapshot file names in chronological order
+func (s *Store) files() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// load reads a snapshot file
+func (s *Store) load(name string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", name, err)
+	}
+	return &snap, nil
+}
+
+// prune keeps every snapshot of the last KeepAllPeriod, the oldest snapshot of
+// each ThinInterval before that, and nothing older than MaxAge, so that --since
+// can reach back days even when watch or serve save a snapshot every few seconds
+func (s *Store) prune(now time.Time) error {
+	files, err := s.files()
+	if err != nil {
+		return err
+	}
+
+	var lastSlot time.Time
+	for _, name := range files {
+		createdAt, err := time.Parse(fileTimeFormat, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			// Not written by Save; leave it alone
+			continue
+		}
+		age := now.Sub(createdAt)
+		if age <= KeepAllPeriod {
+			break
+		}
+
+		slot := createdAt.Truncate(ThinInterval)
+		if age <= MaxAge && !slot.Equal(lastSlot) {
+			lastSlot = slot
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil {
+			return fmt.Errorf("failed to remove old snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// scopeDirName returns a readable, unique directory name for a scope
+// (e.g., "org-my-org-3f2a9c1d")
+func scopeDirName(scope string) string {
+	sum := sha256.Sum256([]byte(scope))
+	hash := hex.EncodeToString(sum[:])[:8]
+
+	name := strings.Trim(unsafeNameChars.ReplaceAllString(scope, "-"), "-")
+	if len(name) > 60 {
+		name = name[:60]
+	}
+	if name == "" {
+		return hash
+	}
+	return name + "-" + hash
+}
@@ -0,0 +1,192 @@
+package sorter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Sort fields
+const (
+	FieldAge        = "age"
+	FieldRepo       = "repo"
+	FieldBot        = "bot"
+	FieldCI         = "ci"
+	FieldMergeable  = "mergeable"
+	FieldUpdateType = "update-type"
+	FieldDependency = "dependency"
+	FieldNumber     = "number"
+)
+
+// Fields lists the sort fields in the order the TUI cycles through them
+var Fields = []string{FieldRepo, FieldAge, FieldCI, FieldMergeable, FieldUpdateType, FieldBot, FieldDependency, FieldNumber}
+
+// Key is a single sort key with its direction
+type Key struct {
+	Field      string
+	Descending bool
+}
+
+// String returns the key in "field:asc" or "field:desc" form
+func (k Key) String() string {
+	if k.Descending {
+		return k.Field + ":desc"
+	}
+	return k.Field + ":asc"
+}
+
+// DefaultKeys sorts by repository name, as the table always did
+var DefaultKeys = []Key{{Field: FieldRepo}}
+
+// ParseKeys parses a comma-separated list of sort keys.
+// Each key is a field optionally followed by ":asc" or ":desc", or prefixed
+// with "-" for descending order (e.g., "ci,age:desc" or "ci,-age").
+func ParseKeys(spec string) ([]Key, error) {
+	var keys []Key
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key := Key{}
+		if strings.HasPrefix(item, "-") {
+			key.Descending = true
+			item = item[1:]
+		}
+		field, direction, hasDirection := strings.Cut(item, ":")
+		if hasDirection {
+			switch strings.ToLower(direction) {
+			case "asc":
+				key.Descending = false
+			case "desc":
+				key.Descending = true
+			default:
+				return nil, fmt.Errorf("invalid sort direction: %s (expected asc or desc)", direction)
+			}
+		}
+
+		key.Field = strings.ToLower(field)
+		if !isField(key.Field) {
+			return nil, fmt.Errorf("invalid sort field: %s (expected one of %s)", field, strings.Join(Fields, ", "))
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return DefaultKeys, nil
+	}
+	return keys, nil
+}
+
+// isField reports whether name is a known sort field
+func isField(name string) bool {
+	for _, field := range Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort sorts PRs in place by the given keys.
+// The sort is stable, and ties are broken by repository and PR number so that
+// the order does not change between refreshes.
+func Sort(prs []models.PullRequest, keys []Key) {
+	sort.SliceStable(prs, func(i, j int) bool {
+		for _, key := range keys {
+			c := compare(&prs[i], &prs[j], key.Field)
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+
+		if c := strings.Compare(prs[i].Repository, prs[j].Repository); c != 0 {
+			return c < 0
+		}
+		return prs[i].Number < prs[j].Number
+	})
+}
+
+// compare returns -1, 0 or 1 comparing a and b by field in ascending order
+func compare(a, b *models.PullRequest, field string) int {
+	switch field {
+	case FieldAge:
+		// Ascending age means newest first
+		return -compareTimes(a, b)
+	case FieldRepo:
+		if c := strings.Compare(a.RepoName(), b.RepoName()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Repository, b.Repository)
+	case FieldBot:
+		return strings.Compare(string(a.BotType), string(b.BotType))
+	case FieldCI:
+		return compareInts(ciRank(a.CheckSummary.Status), ciRank(b.CheckSummary.Status))
+	case FieldMergeable:
+		return compareInts(mergeableRank(a.MergeableState), mergeableRank(b.MergeableState))
+	case FieldUpdateType:
+		return compareInts(a.UpdateType.Rank(), b.UpdateType.Rank())
+	case FieldDependency:
+		return strings.Compare(strings.ToLower(a.Dependency), strings.ToLower(b.Dependency))
+	case FieldNumber:
+		return compareInts(a.Number, b.Number)
+	default:
+		return 0
+	}
+}
+
+// ciRank orders CI statuses so that failures come first in ascending order
+func ciRank(status models.CheckStatus) int {
+	switch status {
+	case models.StatusFailure:
+		return 0
+	case models.StatusPending:
+		return 1
+	case models.StatusSuccess:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// mergeableRank orders mergeable states so that conflicts come first in ascending order
+func mergeableRank(state models.MergeableState) int {
+	switch state {
+	case models.MergeableStateConflicting:
+		return 0
+	case models.MergeableStateUnknown:
+		return 1
+	case models.MergeableStateMergeable:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func compareTimes(a, b *models.PullRequest) int {
+	switch {
+	case a.CreatedAt.Before(b.CreatedAt):
+		return -1
+	case a.CreatedAt.After(b.CreatedAt):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/swfz/gh-deps/internal/models"
+)
+
+// Percentiles summarizes a distribution of durations
+type Percentiles struct {
+	P50 time.Duration
+	P75 time.Duration
+	P90 time.Duration
+	Max time.Duration
+}
+
+// GroupStats summarizes the PRs of a repository or bot
+type GroupStats struct {
+	Name            string
+	Open            int           // Currently open PRs
+	Merged          int           // PRs merged in the period
+	Closed          int           // PRs closed without merging in the period
+	MeanTimeToMerge time.Duration // Mean time from creation to merge (0 = nothing merged)
+	OldestOpenAge   time.Duration // Age of the oldest open PR (0 = nothing open)
+
+	totalTimeToMerge time.Duration
+}
+
+// MergeRatio returns the fraction of finished PRs that were merged, or -1 if none finished
+func (g *GroupStats) MergeRatio() float64 {
+	if g.Merged+g.Closed == 0 {
+		return -1
+	}
+	return float64(g.Merged) / float64(g.Merged+g.Closed)
+}
+
+// Report is the aging and throughput summary of dependency PRs
+type Report struct {
+	Since        time.Time    // Start of the history period
+	Now          time.Time    // Time ages are measured at
+	OpenAge      Percentiles  // Age distribution of open PRs
+	Total        GroupStats   // Totals over all PRs
+	Bots         []GroupStats // Per bot, by name
+	Repositories []GroupStats // Per repository, oldest open PR first
+}
+
+// Compute builds a report from the open PRs and the PRs merged or closed since the given time
+func Compute(open []models.PullRequest, closed []models.ClosedPullRequest, since, now time.Time) *Report {
+	report := &Report{Since: since, Now: now, Total: GroupStats{Name: "total"}}
+	repos := make(map[string]*GroupStats)
+	bots := make(map[string]*GroupStats)
+
+	group := func(groups map[string]*GroupStats, name string) *GroupStats {
+		g, ok := groups[name]
+		if !ok {
+			g = &GroupStats{Name: name}
+			groups[name] = g
+		}
+		return g
+	}
+
+	var ages []time.Duration
+	for _, pr := range open {
+		age := pr.Age(now)
+		ages = append(ages, age)
+		for _, g := range []*GroupStats{&report.Total, group(repos, pr.Repository), group(bots, string(pr.BotType))} {
+			g.Open++
+			if age > g.OldestOpenAge {
+				g.OldestOpenAge = age
+			}
+		}
+	}
+
+	for _, pr := range closed {
+		for _, g := range []*GroupStats{&report.Total, group(repos, pr.Repository), group(bots, string(pr.BotType))} {
+			if pr.Merged {
+				g.Merged++
+				g.totalTimeToMerge += pr.Lifetime()
+			} else {
+				g.Closed++
+			}
+		}
+	}
+
+	report.OpenAge = percentiles(ages)
+	report.Total.finish()
+	report.Bots = sortedGroups(bots, func(a, b *GroupStats) bool { return a.Name < b.Name })
+	report.Repositories = sortedGroups(repos, func(a, b *GroupStats) bool {
+		if a.OldestOpenAge != b.OldestOpenAge {
+			return a.OldestOpenAge > b.OldestOpenAge
+		}
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		return a.Name < b.Name
+	})
+
+	return report
+}
+
+// finish computes derived values once all PRs are counted
+func (g *GroupStats) finish() {
+	if g.Merged > 0 {
+		g.MeanTimeToMerge = g.totalTimeToMerge / time.Duration(g.Merged)
+	}
+}
+
+// sortedGroups returns the finished groups sorted with less
+func sortedGroups(groups map[string]*GroupStats, less func(a, b *GroupStats) bool) []GroupStats {
+	list := make([]*GroupStats, 0, len(groups))
+	for _, g := range groups {
+		g.finish()
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool { return less(list[i], list[j]) })
+
+	result := make([]GroupStats, len(list))
+	for i, g := range list {
+		result[i] = *g
+	}
+	return result
+}
+
+// percentiles computes nearest-rank percentiles of durations
+func percentiles(durations []time.Duration) Percentiles {
+	if len(durations) == 0 {
+		return Percentiles{}
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p int) time.Duration {
+		index := (p*len(sorted)+99)/100 - 1
+		if index < 0 {
+			index = 0
+		}
+		return sorted[index]
+	}
+
+	return Percentiles{
+		P50: rank(50),
+		P75: rank(75),
+		P90: rank(90),
+		Max: sorted[len(sorted)-1],
+	}
+}